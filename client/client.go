@@ -0,0 +1,98 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client is a small typed client for the Atlassian Statuspage REST API.
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+)
+
+// DefaultBaseURL is the base URL of the Statuspage v1 API.
+const DefaultBaseURL = "https://api.statuspage.io/v1"
+
+// Client is a Statuspage API client. The zero value is not usable, create one with New.
+type Client struct {
+	// BaseURL is the API endpoint requests are sent to, without a trailing slash.
+	BaseURL string
+	// APIKey is the key used to authenticate against the API.
+	APIKey string
+	// HTTPClient is the underlying client used to send requests.
+	HTTPClient *http.Client
+}
+
+// New returns a client for the public Statuspage API authenticated with apiKey. Requests are
+// rate limited and retried with the defaults of NewRetryTransport.
+func New(apiKey string) *Client {
+	return &Client{
+		BaseURL:    DefaultBaseURL,
+		APIKey:     apiKey,
+		HTTPClient: &http.Client{Transport: NewRetryTransport(DefaultMaxRetries, DefaultTimeout)},
+	}
+}
+
+// do sends a request to path, encoding in as the JSON body when not nil and
+// decoding the JSON response into out when not nil. Non-2xx responses are returned as an *APIError.
+func (c *Client) do(method, path string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		jsonBody, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewBuffer(jsonBody)
+	}
+
+	request, err := http.NewRequest(method, strings.TrimSuffix(c.BaseURL, "/")+path, body)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "OAuth "+c.APIKey)
+	if in != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(request)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return newAPIError(resp.StatusCode, respBody)
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(respBody, out)
+}
+
+func pagePath(pageID string, elem ...string) string {
+	return "/pages/" + pageID + "/" + strings.Join(elem, "/")
+}
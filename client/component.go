@@ -0,0 +1,97 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// ComponentStatuses are the statuses a component can be set to.
+var ComponentStatuses = []string{"operational", "under_maintenance", "degraded_performance", "partial_outage", "major_outage"}
+
+// Component is a piece of infrastructure whose status is shown on a page.
+type Component struct {
+	ID                 string    `json:"id"`
+	PageID             string    `json:"page_id"`
+	GroupID            string    `json:"group_id"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	Group              bool      `json:"group"`
+	Name               string    `json:"name"`
+	Description        string    `json:"description"`
+	Position           int       `json:"position"`
+	Status             string    `json:"status"`
+	Showcase           bool      `json:"showcase"`
+	OnlyShowIfDegraded bool      `json:"only_show_if_degraded"`
+	AutomationEmail    string    `json:"automation_email"`
+	StartDate          string    `json:"start_date"`
+}
+
+// ComponentParams are the fields sent when creating or updating a component. Nil fields are
+// left out of the request so an update only changes the fields that are set.
+type ComponentParams struct {
+	Name               *string `json:"name,omitempty"`
+	Description        *string `json:"description,omitempty"`
+	Status             *string `json:"status,omitempty"`
+	Showcase           *bool   `json:"showcase,omitempty"`
+	GroupID            *string `json:"group_id,omitempty"`
+	OnlyShowIfDegraded *bool   `json:"only_show_if_degraded,omitempty"`
+	StartDate          *string `json:"start_date,omitempty"`
+}
+
+type componentRequest struct {
+	Component ComponentParams `json:"component"`
+}
+
+// ListComponents returns the components of a page selected by opts.
+func (c *Client) ListComponents(pageID string, opts *ListOptions) ([]Component, error) {
+	return listAll[Component](c, pagePath(pageID, "components"), "per_page", opts)
+}
+
+// EachComponents calls fn with every page of components selected by opts as it is fetched.
+func (c *Client) EachComponents(pageID string, opts *ListOptions, fn func([]Component) error) error {
+	return eachPage(c, pagePath(pageID, "components"), "per_page", opts, fn)
+}
+
+// GetComponent returns a single component of a page.
+func (c *Client) GetComponent(pageID, componentID string) (*Component, error) {
+	component := &Component{}
+	if err := c.do("GET", pagePath(pageID, "components", componentID), nil, component); err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+// CreateComponent creates a component on a page.
+func (c *Client) CreateComponent(pageID string, params ComponentParams) (*Component, error) {
+	component := &Component{}
+	if err := c.do("POST", pagePath(pageID, "components"), componentRequest{params}, component); err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+// UpdateComponent updates a component of a page.
+func (c *Client) UpdateComponent(pageID, componentID string, params ComponentParams) (*Component, error) {
+	component := &Component{}
+	if err := c.do("PATCH", pagePath(pageID, "components", componentID), componentRequest{params}, component); err != nil {
+		return nil, err
+	}
+	return component, nil
+}
+
+// DeleteComponent deletes a component of a page.
+func (c *Client) DeleteComponent(pageID, componentID string) error {
+	return c.do("DELETE", pagePath(pageID, "components", componentID), nil, nil)
+}
@@ -0,0 +1,84 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// ComponentGroup is a named group of components on a page.
+type ComponentGroup struct {
+	ID          string    `json:"id"`
+	PageID      string    `json:"page_id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Components  []string  `json:"components"`
+	Position    int       `json:"position"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// ComponentGroupParams are the fields sent when creating or updating a component group. Nil and
+// empty fields are left out of the request. Components replaces the members of the group.
+type ComponentGroupParams struct {
+	Name       *string  `json:"name,omitempty"`
+	Components []string `json:"components,omitempty"`
+}
+
+// The API takes the description of a group next to the group rather than in it.
+type componentGroupRequest struct {
+	Description    *string              `json:"description,omitempty"`
+	ComponentGroup ComponentGroupParams `json:"component_group"`
+}
+
+// ListComponentGroups returns the component groups of a page selected by opts.
+func (c *Client) ListComponentGroups(pageID string, opts *ListOptions) ([]ComponentGroup, error) {
+	return listAll[ComponentGroup](c, pagePath(pageID, "component-groups"), "per_page", opts)
+}
+
+// GetComponentGroup returns a single component group of a page.
+func (c *Client) GetComponentGroup(pageID, groupID string) (*ComponentGroup, error) {
+	group := &ComponentGroup{}
+	if err := c.do("GET", pagePath(pageID, "component-groups", groupID), nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// CreateComponentGroup creates a component group on a page. A group needs at least one component.
+func (c *Client) CreateComponentGroup(pageID string, description *string, params ComponentGroupParams) (*ComponentGroup, error) {
+	group := &ComponentGroup{}
+	if err := c.do("POST", pagePath(pageID, "component-groups"), componentGroupRequest{description, params}, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// UpdateComponentGroup updates a component group of a page.
+func (c *Client) UpdateComponentGroup(pageID, groupID string, description *string, params ComponentGroupParams) (*ComponentGroup, error) {
+	group := &ComponentGroup{}
+	if err := c.do("PATCH", pagePath(pageID, "component-groups", groupID), componentGroupRequest{description, params}, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeleteComponentGroup deletes a component group of a page. Its components are kept and moved out of the group.
+func (c *Client) DeleteComponentGroup(pageID, groupID string) (*ComponentGroup, error) {
+	group := &ComponentGroup{}
+	if err := c.do("DELETE", pagePath(pageID, "component-groups", groupID), nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
@@ -0,0 +1,125 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// APIError is returned when the API responds with a non-2xx status code.
+type APIError struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Message is the error message returned by the API, or the status text when there is none.
+	Message string
+	// FieldErrors are validation errors keyed by the name of the offending field.
+	FieldErrors map[string][]string
+}
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%d %s", e.StatusCode, e.Message)
+
+	fields := make([]string, 0, len(e.FieldErrors))
+	for field := range e.FieldErrors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+
+	for _, field := range fields {
+		msg += fmt.Sprintf("; %s %s", field, strings.Join(e.FieldErrors[field], ", "))
+	}
+	return msg
+}
+
+// IsUnauthorized reports whether the API key was missing, invalid or not allowed to perform the request.
+func (e *APIError) IsUnauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+}
+
+// IsNotFound reports whether the requested resource does not exist.
+func (e *APIError) IsNotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
+
+// IsValidation reports whether the request was rejected as invalid.
+func (e *APIError) IsValidation() bool {
+	return e.StatusCode == http.StatusBadRequest || e.StatusCode == http.StatusUnprocessableEntity
+}
+
+// IsRateLimited reports whether the request was throttled.
+func (e *APIError) IsRateLimited() bool {
+	return e.StatusCode == http.StatusTooManyRequests
+}
+
+// IsServerError reports whether the API failed to handle the request.
+func (e *APIError) IsServerError() bool {
+	return e.StatusCode >= 500
+}
+
+// newAPIError builds an APIError from a response. Statuspage reports errors as
+// {"error": "message"}, {"error": ["message", ...]} or {"error": {"field": ["message", ...]}},
+// and some endpoints use "message" instead of "error".
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode}
+
+	var payload map[string]json.RawMessage
+	if err := json.Unmarshal(body, &payload); err == nil {
+		for _, key := range []string{"error", "errors", "message"} {
+			if raw, ok := payload[key]; ok {
+				apiErr.parse(raw)
+				break
+			}
+		}
+	}
+
+	if apiErr.Message == "" {
+		apiErr.Message = http.StatusText(statusCode)
+	}
+	return apiErr
+}
+
+func (e *APIError) parse(raw json.RawMessage) {
+	var message string
+	if err := json.Unmarshal(raw, &message); err == nil {
+		e.Message = message
+		return
+	}
+
+	var messages []string
+	if err := json.Unmarshal(raw, &messages); err == nil {
+		e.Message = strings.Join(messages, "; ")
+		return
+	}
+
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &fields); err == nil {
+		e.FieldErrors = map[string][]string{}
+		for field, value := range fields {
+			var fieldMessages []string
+			if err := json.Unmarshal(value, &fieldMessages); err != nil {
+				var fieldMessage string
+				json.Unmarshal(value, &fieldMessage)
+				fieldMessages = []string{fieldMessage}
+			}
+			e.FieldErrors[field] = fieldMessages
+		}
+		e.Message = http.StatusText(e.StatusCode)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// IncidentStatuses are the statuses an incident or scheduled maintenance can be set to.
+var IncidentStatuses = []string{"investigating", "identified", "monitoring", "resolved", "scheduled", "in_progress", "verifying", "completed"}
+
+// MaintenanceStatuses are the statuses a scheduled maintenance can be set to.
+var MaintenanceStatuses = []string{"scheduled", "in_progress", "verifying", "completed"}
+
+// Incident is an incident or scheduled maintenance on a page.
+type Incident struct {
+	ID              string           `json:"id"`
+	PageID          string           `json:"page_id"`
+	Name            string           `json:"name"`
+	Status          string           `json:"status"`
+	Impact          string           `json:"impact"`
+	Shortlink       string           `json:"shortlink"`
+	CreatedAt       time.Time        `json:"created_at"`
+	UpdatedAt       time.Time        `json:"updated_at"`
+	StartedAt       *time.Time       `json:"started_at"`
+	MonitoringAt    *time.Time       `json:"monitoring_at"`
+	ResolvedAt      *time.Time       `json:"resolved_at"`
+	IncidentUpdates []IncidentUpdate `json:"incident_updates"`
+	Components      []Component      `json:"components"`
+
+	ScheduledFor            *time.Time `json:"scheduled_for"`
+	ScheduledUntil          *time.Time `json:"scheduled_until"`
+	ScheduledRemindPrior    bool       `json:"scheduled_remind_prior"`
+	ScheduledAutoInProgress bool       `json:"scheduled_auto_in_progress"`
+	ScheduledAutoCompleted  bool       `json:"scheduled_auto_completed"`
+}
+
+// IncidentUpdate is a single entry in the timeline of an incident.
+type IncidentUpdate struct {
+	ID                   string              `json:"id"`
+	IncidentID           string              `json:"incident_id"`
+	Status               string              `json:"status"`
+	Body                 string              `json:"body"`
+	CreatedAt            time.Time           `json:"created_at"`
+	UpdatedAt            time.Time           `json:"updated_at"`
+	DisplayAt            *time.Time          `json:"display_at"`
+	AffectedComponents   []AffectedComponent `json:"affected_components"`
+	DeliverNotifications bool                `json:"deliver_notifications"`
+	WantsTwitterUpdate   bool                `json:"wants_twitter_update"`
+	CustomTweet          string              `json:"custom_tweet"`
+	TweetID              string              `json:"tweet_id"`
+	TwitterUpdatedAt     *time.Time          `json:"twitter_updated_at"`
+}
+
+// AffectedComponent is the change of status of a component recorded by an incident update.
+type AffectedComponent struct {
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	OldStatus string `json:"old_status"`
+	NewStatus string `json:"new_status"`
+}
+
+// IncidentUpdateParams are the fields sent when editing an incident update. Nil fields are left
+// out of the request.
+type IncidentUpdateParams struct {
+	Body                 *string    `json:"body,omitempty"`
+	DisplayAt            *time.Time `json:"display_at,omitempty"`
+	WantsTwitterUpdate   *bool      `json:"wants_twitter_update,omitempty"`
+	DeliverNotifications *bool      `json:"deliver_notifications,omitempty"`
+}
+
+type incidentUpdateRequest struct {
+	IncidentUpdate IncidentUpdateParams `json:"incident_update"`
+}
+
+// IncidentParams are the fields sent when creating or updating an incident. Nil and empty
+// fields are left out of the request so an update only changes the fields that are set.
+type IncidentParams struct {
+	Name         *string           `json:"name,omitempty"`
+	Status       *string           `json:"status,omitempty"`
+	Body         *string           `json:"body,omitempty"`
+	ComponentIDs []string          `json:"component_ids,omitempty"`
+	Components   map[string]string `json:"components,omitempty"`
+
+	// DeliverNotifications controls whether subscribers are notified of the incident update.
+	DeliverNotifications *bool `json:"deliver_notifications,omitempty"`
+
+	ScheduledFor            *time.Time `json:"scheduled_for,omitempty"`
+	ScheduledUntil          *time.Time `json:"scheduled_until,omitempty"`
+	ScheduledRemindPrior    *bool      `json:"scheduled_remind_prior,omitempty"`
+	ScheduledAutoInProgress *bool      `json:"scheduled_auto_in_progress,omitempty"`
+	ScheduledAutoCompleted  *bool      `json:"scheduled_auto_completed,omitempty"`
+}
+
+type incidentRequest struct {
+	Incident IncidentParams `json:"incident"`
+}
+
+// ListIncidents returns the incidents of a page selected by opts, most recent first.
+func (c *Client) ListIncidents(pageID string, opts *ListOptions) ([]Incident, error) {
+	return listAll[Incident](c, pagePath(pageID, "incidents"), "limit", opts)
+}
+
+// EachIncidents calls fn with every page of incidents selected by opts as it is fetched.
+func (c *Client) EachIncidents(pageID string, opts *ListOptions, fn func([]Incident) error) error {
+	return eachPage(c, pagePath(pageID, "incidents"), "limit", opts, fn)
+}
+
+// GetIncident returns a single incident of a page.
+func (c *Client) GetIncident(pageID, incidentID string) (*Incident, error) {
+	incident := &Incident{}
+	if err := c.do("GET", pagePath(pageID, "incidents", incidentID), nil, incident); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// CreateIncident opens an incident on a page.
+func (c *Client) CreateIncident(pageID string, params IncidentParams) (*Incident, error) {
+	incident := &Incident{}
+	if err := c.do("POST", pagePath(pageID, "incidents"), incidentRequest{params}, incident); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// UpdateIncident updates an incident of a page, adding an incident update when a body is given.
+func (c *Client) UpdateIncident(pageID, incidentID string, params IncidentParams) (*Incident, error) {
+	incident := &Incident{}
+	if err := c.do("PATCH", pagePath(pageID, "incidents", incidentID), incidentRequest{params}, incident); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// DeleteIncident deletes an incident of a page and returns it.
+func (c *Client) DeleteIncident(pageID, incidentID string) (*Incident, error) {
+	incident := &Incident{}
+	if err := c.do("DELETE", pagePath(pageID, "incidents", incidentID), nil, incident); err != nil {
+		return nil, err
+	}
+	return incident, nil
+}
+
+// ListIncidentUpdates returns the timeline of an incident, most recent update first.
+func (c *Client) ListIncidentUpdates(pageID, incidentID string) ([]IncidentUpdate, error) {
+	incident, err := c.GetIncident(pageID, incidentID)
+	if err != nil {
+		return nil, err
+	}
+	return incident.IncidentUpdates, nil
+}
+
+// UpdateIncidentUpdate edits an update in the timeline of an incident.
+func (c *Client) UpdateIncidentUpdate(pageID, incidentID, incidentUpdateID string, params IncidentUpdateParams) (*IncidentUpdate, error) {
+	incidentUpdate := &IncidentUpdate{}
+	path := pagePath(pageID, "incidents", incidentID, "incident_updates", incidentUpdateID)
+	if err := c.do("PATCH", path, incidentUpdateRequest{params}, incidentUpdate); err != nil {
+		return nil, err
+	}
+	return incidentUpdate, nil
+}
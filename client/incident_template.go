@@ -0,0 +1,71 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// IncidentTemplate is a template prefilling the name, body and component statuses of incidents.
+type IncidentTemplate struct {
+	ID                      string      `json:"id"`
+	GroupID                 string      `json:"group_id"`
+	Name                    string      `json:"name"`
+	UpdateStatus            string      `json:"update_status"`
+	Title                   string      `json:"title"`
+	Body                    string      `json:"body"`
+	Components              []Component `json:"components"`
+	ShouldTweet             bool        `json:"should_tweet"`
+	ShouldSendNotifications bool        `json:"should_send_notifications"`
+	CreatedAt               time.Time   `json:"created_at"`
+	UpdatedAt               time.Time   `json:"updated_at"`
+}
+
+// IncidentTemplateParams are the fields sent when creating an incident template. Nil and empty
+// fields are left out of the request.
+type IncidentTemplateParams struct {
+	Name                    *string  `json:"name,omitempty"`
+	Title                   *string  `json:"title,omitempty"`
+	Body                    *string  `json:"body,omitempty"`
+	GroupID                 *string  `json:"group_id,omitempty"`
+	UpdateStatus            *string  `json:"update_status,omitempty"`
+	ShouldTweet             *bool    `json:"should_tweet,omitempty"`
+	ShouldSendNotifications *bool    `json:"should_send_notifications,omitempty"`
+	ComponentIDs            []string `json:"component_ids,omitempty"`
+}
+
+type incidentTemplateRequest struct {
+	Template IncidentTemplateParams `json:"template"`
+}
+
+// ListIncidentTemplates returns the incident templates of a page selected by opts.
+func (c *Client) ListIncidentTemplates(pageID string, opts *ListOptions) ([]IncidentTemplate, error) {
+	return listAll[IncidentTemplate](c, pagePath(pageID, "incident_templates"), "per_page", opts)
+}
+
+// CreateIncidentTemplate creates an incident template on a page.
+func (c *Client) CreateIncidentTemplate(pageID string, params IncidentTemplateParams) (*IncidentTemplate, error) {
+	template := &IncidentTemplate{}
+	if err := c.do("POST", pagePath(pageID, "incident_templates"), incidentTemplateRequest{params}, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// DeleteIncidentTemplate deletes an incident template of a page. The public API only documents
+// listing and creating templates, so the request is rejected with a 404 or 405 *APIError where
+// deleting is not available.
+func (c *Client) DeleteIncidentTemplate(pageID, templateID string) error {
+	return c.do("DELETE", pagePath(pageID, "incident_templates", templateID), nil, nil)
+}
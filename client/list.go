@@ -0,0 +1,124 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// DefaultPerPage is the page size used when following all pages without an explicit page size.
+const DefaultPerPage = 100
+
+// ListOptions select the results returned by list requests. A nil or zero ListOptions fetches
+// the first page with the API's default page size.
+type ListOptions struct {
+	// Page is the first page to fetch, starting at 1.
+	Page int
+	// PerPage is the number of results requested per page.
+	PerPage int
+	// All follows pages until every result has been fetched.
+	All bool
+	// Limit caps the total number of results, following pages until it is reached. No cap when zero.
+	Limit int
+	// Query holds extra query parameters sent with every page, such as search terms and filters.
+	Query url.Values
+}
+
+// paginate calls fetch with the path of each page to request and the maximum number of results
+// wanted from it (zero for no maximum). fetch returns the number of results the API sent back,
+// which is used to tell when the last page was reached. sizeParam is the query parameter the
+// endpoint uses for the page size, either "per_page" or "limit".
+func (c *Client) paginate(path, sizeParam string, opts *ListOptions, fetch func(path string, max int) (int, error)) error {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+
+	page := opts.Page
+	follow := opts.All || opts.Limit > 0
+	perPage := opts.PerPage
+	if follow && perPage == 0 {
+		perPage = DefaultPerPage
+	}
+	// Pages larger than the limit would fetch results that are thrown away.
+	if opts.Limit > 0 && (perPage == 0 || perPage > opts.Limit) {
+		perPage = opts.Limit
+	}
+	remaining := opts.Limit
+
+	for {
+		query := url.Values{}
+		for key, values := range opts.Query {
+			query[key] = values
+		}
+		if page > 0 {
+			query.Set("page", strconv.Itoa(page))
+		}
+		if perPage > 0 {
+			query.Set(sizeParam, strconv.Itoa(perPage))
+		}
+
+		pagePath := path
+		if len(query) > 0 {
+			pagePath += "?" + query.Encode()
+		}
+
+		n, err := fetch(pagePath, remaining)
+		if err != nil {
+			return err
+		}
+
+		if !follow || n == 0 || n < perPage {
+			return nil
+		}
+		if opts.Limit > 0 {
+			remaining -= n
+			if remaining <= 0 {
+				return nil
+			}
+		}
+
+		if page == 0 {
+			page = 1
+		}
+		page++
+	}
+}
+
+// eachPage calls fn with every page of results of path selected by opts as it is fetched.
+func eachPage[T any](c *Client, path, sizeParam string, opts *ListOptions, fn func([]T) error) error {
+	return c.paginate(path, sizeParam, opts, func(path string, max int) (int, error) {
+		var page []T
+		if err := c.do("GET", path, nil, &page); err != nil {
+			return 0, err
+		}
+		n := len(page)
+		if max > 0 && n > max {
+			page = page[:max]
+		}
+		return n, fn(page)
+	})
+}
+
+// listAll returns the results of path selected by opts, from every page fetched.
+func listAll[T any](c *Client, path, sizeParam string, opts *ListOptions) ([]T, error) {
+	results := []T{}
+	err := eachPage(c, path, sizeParam, opts, func(page []T) error {
+		results = append(results, page...)
+		return nil
+	})
+	return results, err
+}
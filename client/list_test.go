@@ -0,0 +1,150 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strconv"
+	"testing"
+)
+
+type item struct {
+	ID int `json:"id"`
+}
+
+// pagedServer serves total items numbered from 1, split in pages selected by the page and
+// sizeParam query parameters, and records the query of every request.
+func pagedServer(total int, sizeParam string, queries *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*queries = append(*queries, r.URL.RawQuery)
+
+		page, size := 1, 3
+		if value := r.URL.Query().Get("page"); value != "" {
+			page, _ = strconv.Atoi(value)
+		}
+		if value := r.URL.Query().Get(sizeParam); value != "" {
+			size, _ = strconv.Atoi(value)
+		}
+
+		items := []item{}
+		for id := (page-1)*size + 1; id <= page*size && id <= total; id++ {
+			items = append(items, item{id})
+		}
+		json.NewEncoder(w).Encode(items)
+	}))
+}
+
+func ids(n ...int) []item {
+	items := []item{}
+	for _, id := range n {
+		items = append(items, item{id})
+	}
+	return items
+}
+
+func TestEachPage(t *testing.T) {
+	tests := []struct {
+		name      string
+		total     int
+		sizeParam string
+		opts      *ListOptions
+		want      []item
+		queries   []string
+	}{
+		{"first page by default", 5, "per_page", nil, ids(1, 2, 3), []string{""}},
+		{"page and page size", 5, "per_page", &ListOptions{Page: 2, PerPage: 2}, ids(3, 4), []string{"page=2&per_page=2"}},
+		{"all with default page size", 5, "per_page", &ListOptions{All: true}, ids(1, 2, 3, 4, 5), []string{"per_page=100"}},
+		{"all pages", 5, "per_page", &ListOptions{All: true, PerPage: 2}, ids(1, 2, 3, 4, 5),
+			[]string{"per_page=2", "page=2&per_page=2", "page=3&per_page=2"}},
+		{"all pages from a page", 5, "per_page", &ListOptions{All: true, Page: 2, PerPage: 2}, ids(3, 4, 5),
+			[]string{"page=2&per_page=2", "page=3&per_page=2"}},
+		{"last page full", 4, "per_page", &ListOptions{All: true, PerPage: 2}, ids(1, 2, 3, 4),
+			[]string{"per_page=2", "page=2&per_page=2", "page=3&per_page=2"}},
+		{"limit across pages", 5, "per_page", &ListOptions{Limit: 3, PerPage: 2}, ids(1, 2, 3),
+			[]string{"per_page=2", "page=2&per_page=2"}},
+		{"limit within a page", 5, "limit", &ListOptions{Limit: 2}, ids(1, 2), []string{"limit=2"}},
+		{"limit below the page size", 5, "per_page", &ListOptions{Limit: 2, PerPage: 10}, ids(1, 2), []string{"per_page=2"}},
+		{"limit size parameter", 5, "limit", &ListOptions{All: true, PerPage: 3}, ids(1, 2, 3, 4, 5),
+			[]string{"limit=3", "limit=3&page=2"}},
+		{"query", 2, "per_page", &ListOptions{Query: url.Values{"q": {"api"}}}, ids(1, 2), []string{"q=api"}},
+		{"no results", 0, "per_page", &ListOptions{All: true}, ids(), []string{"per_page=100"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var queries []string
+			server := pagedServer(test.total, test.sizeParam, &queries)
+			defer server.Close()
+			c := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+			got := []item{}
+			pages := 0
+			err := eachPage(c, "/items", test.sizeParam, test.opts, func(page []item) error {
+				pages++
+				got = append(got, page...)
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			if !reflect.DeepEqual(queries, test.queries) {
+				t.Errorf("requested %q, want %q", queries, test.queries)
+			}
+			if pages != len(test.queries) {
+				t.Errorf("called fn %d times, want once per request (%d)", pages, len(test.queries))
+			}
+
+			all, err := listAll[item](c, "/items", test.sizeParam, test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(all, test.want) {
+				t.Errorf("listAll returned %v, want %v", all, test.want)
+			}
+		})
+	}
+}
+
+func TestEachPageError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			http.Error(w, `{"error":"not found"}`, http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(ids(1, 2))
+	}))
+	defer server.Close()
+	c := &Client{BaseURL: server.URL, HTTPClient: server.Client()}
+
+	var got []item
+	err := eachPage(c, "/items", "per_page", &ListOptions{All: true, PerPage: 2}, func(page []item) error {
+		got = append(got, page...)
+		return nil
+	})
+	if apiErr, ok := err.(*APIError); !ok || !apiErr.IsNotFound() {
+		t.Errorf("got error %v, want a not found error", err)
+	}
+	if !reflect.DeepEqual(got, ids(1, 2)) {
+		t.Errorf("got %v before the error, want the first page", got)
+	}
+}
@@ -0,0 +1,53 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+// Scheduled maintenances are incidents with a scheduled_for and scheduled_until window. They are
+// created, updated and deleted with the incident methods, and listed with the methods below.
+
+// ListScheduledMaintenances returns the maintenances of a page in the scheduled state selected by opts.
+func (c *Client) ListScheduledMaintenances(pageID string, opts *ListOptions) ([]Incident, error) {
+	return c.listMaintenances(pagePath(pageID, "incidents", "scheduled"), opts)
+}
+
+// ListUpcomingMaintenances returns the scheduled maintenances of a page that have not started yet.
+func (c *Client) ListUpcomingMaintenances(pageID string, opts *ListOptions) ([]Incident, error) {
+	return c.listMaintenances(pagePath(pageID, "incidents", "upcoming"), opts)
+}
+
+// ListActiveMaintenances returns the scheduled maintenances of a page that are in progress or being verified.
+func (c *Client) ListActiveMaintenances(pageID string, opts *ListOptions) ([]Incident, error) {
+	return c.listMaintenances(pagePath(pageID, "incidents", "active_maintenance"), opts)
+}
+
+func (c *Client) listMaintenances(path string, opts *ListOptions) ([]Incident, error) {
+	return listAll[Incident](c, path, "per_page", opts)
+}
+
+// EachScheduledMaintenances calls fn with every page of scheduled maintenances selected by opts as it is fetched.
+func (c *Client) EachScheduledMaintenances(pageID string, opts *ListOptions, fn func([]Incident) error) error {
+	return eachPage(c, pagePath(pageID, "incidents", "scheduled"), "per_page", opts, fn)
+}
+
+// EachUpcomingMaintenances calls fn with every page of upcoming maintenances selected by opts as it is fetched.
+func (c *Client) EachUpcomingMaintenances(pageID string, opts *ListOptions, fn func([]Incident) error) error {
+	return eachPage(c, pagePath(pageID, "incidents", "upcoming"), "per_page", opts, fn)
+}
+
+// EachActiveMaintenances calls fn with every page of active maintenances selected by opts as it is fetched.
+func (c *Client) EachActiveMaintenances(pageID string, opts *ListOptions, fn func([]Incident) error) error {
+	return eachPage(c, pagePath(pageID, "incidents", "active_maintenance"), "per_page", opts, fn)
+}
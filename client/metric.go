@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// MetricTransforms are the ways the data points of a metric fed by a monitoring service can be
+// combined.
+var MetricTransforms = []string{"average", "count", "max", "min", "sum", "response_time", "uptime"}
+
+// Metric is a system metric graphed on a page.
+type Metric struct {
+	ID                 string     `json:"id"`
+	MetricsProviderID  string     `json:"metrics_provider_id"`
+	MetricIdentifier   string     `json:"metric_identifier"`
+	Name               string     `json:"name"`
+	Display            bool       `json:"display"`
+	TooltipDescription string     `json:"tooltip_description"`
+	Backfilled         bool       `json:"backfilled"`
+	YAxisMin           float64    `json:"y_axis_min"`
+	YAxisMax           float64    `json:"y_axis_max"`
+	YAxisHidden        bool       `json:"y_axis_hidden"`
+	Suffix             string     `json:"suffix"`
+	DecimalPlaces      int        `json:"decimal_places"`
+	MostRecentDataAt   *time.Time `json:"most_recent_data_at"`
+	CreatedAt          time.Time  `json:"created_at"`
+	UpdatedAt          time.Time  `json:"updated_at"`
+}
+
+// MetricParams are the fields sent when creating or updating a metric. Nil fields are left out of
+// the request so an update only changes the fields that are set.
+type MetricParams struct {
+	Name               *string  `json:"name,omitempty"`
+	MetricIdentifier   *string  `json:"metric_identifier,omitempty"`
+	Transform          *string  `json:"transform,omitempty"`
+	ApplicationID      *string  `json:"application_id,omitempty"`
+	Suffix             *string  `json:"suffix,omitempty"`
+	Display            *bool    `json:"display,omitempty"`
+	TooltipDescription *string  `json:"tooltip_description,omitempty"`
+	DecimalPlaces      *int     `json:"decimal_places,omitempty"`
+	YAxisMin           *float64 `json:"y_axis_min,omitempty"`
+	YAxisMax           *float64 `json:"y_axis_max,omitempty"`
+	YAxisHidden        *bool    `json:"y_axis_hidden,omitempty"`
+}
+
+type metricRequest struct {
+	Metric MetricParams `json:"metric"`
+}
+
+// MetricPoint is a data point of a metric. Timestamp is in seconds since the Unix epoch.
+type MetricPoint struct {
+	Timestamp int64   `json:"timestamp"`
+	Value     float64 `json:"value"`
+}
+
+type metricPointRequest struct {
+	Data MetricPoint `json:"data"`
+}
+
+type metricPointsRequest struct {
+	Data map[string][]MetricPoint `json:"data"`
+}
+
+// ListMetrics returns the metrics of a page selected by opts.
+func (c *Client) ListMetrics(pageID string, opts *ListOptions) ([]Metric, error) {
+	return listAll[Metric](c, pagePath(pageID, "metrics"), "per_page", opts)
+}
+
+// ListProviderMetrics returns the metrics of a metrics provider of a page selected by opts.
+func (c *Client) ListProviderMetrics(pageID, providerID string, opts *ListOptions) ([]Metric, error) {
+	return listAll[Metric](c, pagePath(pageID, "metrics_providers", providerID, "metrics"), "per_page", opts)
+}
+
+// GetMetric returns a single metric of a page.
+func (c *Client) GetMetric(pageID, metricID string) (*Metric, error) {
+	metric := &Metric{}
+	if err := c.do("GET", pagePath(pageID, "metrics", metricID), nil, metric); err != nil {
+		return nil, err
+	}
+	return metric, nil
+}
+
+// CreateMetric creates a metric fed by a metrics provider of a page.
+func (c *Client) CreateMetric(pageID, providerID string, params MetricParams) (*Metric, error) {
+	metric := &Metric{}
+	if err := c.do("POST", pagePath(pageID, "metrics_providers", providerID, "metrics"), metricRequest{params}, metric); err != nil {
+		return nil, err
+	}
+	return metric, nil
+}
+
+// UpdateMetric changes the settings of a metric of a page. Only the fields set in params are changed.
+func (c *Client) UpdateMetric(pageID, metricID string, params MetricParams) (*Metric, error) {
+	metric := &Metric{}
+	if err := c.do("PATCH", pagePath(pageID, "metrics", metricID), metricRequest{params}, metric); err != nil {
+		return nil, err
+	}
+	return metric, nil
+}
+
+// DeleteMetric deletes a metric of a page along with its data points.
+func (c *Client) DeleteMetric(pageID, metricID string) (*Metric, error) {
+	metric := &Metric{}
+	if err := c.do("DELETE", pagePath(pageID, "metrics", metricID), nil, metric); err != nil {
+		return nil, err
+	}
+	return metric, nil
+}
+
+// AddMetricPoint submits a data point to a metric of a page.
+func (c *Client) AddMetricPoint(pageID, metricID string, point MetricPoint) error {
+	return c.do("POST", pagePath(pageID, "metrics", metricID, "data"), metricPointRequest{point}, nil)
+}
+
+// AddMetricPoints submits data points to several metrics of a page in a single request. points
+// is keyed by metric identifier.
+func (c *Client) AddMetricPoints(pageID string, points map[string][]MetricPoint) error {
+	return c.do("POST", pagePath(pageID, "metrics", "data"), metricPointsRequest{points}, nil)
+}
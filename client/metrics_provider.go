@@ -0,0 +1,45 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// MetricsProvider is a source of metrics, either a monitoring service such as Pingdom, New Relic,
+// Librato or Datadog, or "Self" for metrics whose data points are submitted through the API.
+type MetricsProvider struct {
+	ID                string     `json:"id"`
+	PageID            string     `json:"page_id"`
+	Type              string     `json:"type"`
+	Disabled          bool       `json:"disabled"`
+	MetricBaseURI     string     `json:"metric_base_uri"`
+	LastRevalidatedAt *time.Time `json:"last_revalidated_at"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// ListMetricsProviders returns the metrics providers of a page selected by opts.
+func (c *Client) ListMetricsProviders(pageID string, opts *ListOptions) ([]MetricsProvider, error) {
+	return listAll[MetricsProvider](c, pagePath(pageID, "metrics_providers"), "per_page", opts)
+}
+
+// GetMetricsProvider returns a single metrics provider of a page.
+func (c *Client) GetMetricsProvider(pageID, providerID string) (*MetricsProvider, error) {
+	provider := &MetricsProvider{}
+	if err := c.do("GET", pagePath(pageID, "metrics_providers", providerID), nil, provider); err != nil {
+		return nil, err
+	}
+	return provider, nil
+}
@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// Page is a Statuspage status page.
+type Page struct {
+	ID                       string    `json:"id"`
+	CreatedAt                time.Time `json:"created_at"`
+	UpdatedAt                time.Time `json:"updated_at"`
+	Name                     string    `json:"name"`
+	PageDescription          string    `json:"page_description"`
+	Headline                 string    `json:"headline"`
+	Branding                 string    `json:"branding"`
+	Subdomain                string    `json:"subdomain"`
+	Domain                   string    `json:"domain"`
+	URL                      string    `json:"url"`
+	SupportURL               string    `json:"support_url"`
+	TimeZone                 string    `json:"time_zone"`
+	AllowPageSubscribers     bool      `json:"allow_page_subscribers"`
+	AllowIncidentSubscribers bool      `json:"allow_incident_subscribers"`
+	AllowEmailSubscribers    bool      `json:"allow_email_subscribers"`
+	AllowSMSSubscribers      bool      `json:"allow_sms_subscribers"`
+	AllowRSSAtomFeeds        bool      `json:"allow_rss_atom_feeds"`
+	AllowWebhookSubscribers  bool      `json:"allow_webhook_subscribers"`
+	HiddenFromSearch         bool      `json:"hidden_from_search"`
+	ViewersMustBeTeamMembers bool      `json:"viewers_must_be_team_members"`
+	NotificationsFromEmail   string    `json:"notifications_from_email"`
+	NotificationsEmailFooter string    `json:"notifications_email_footer"`
+	TwitterUsername          string    `json:"twitter_username"`
+	CSSBodyBackgroundColor   string    `json:"css_body_background_color"`
+	CSSFontColor             string    `json:"css_font_color"`
+	CSSLightFontColor        string    `json:"css_light_font_color"`
+	CSSGreens                string    `json:"css_greens"`
+	CSSYellows               string    `json:"css_yellows"`
+	CSSOranges               string    `json:"css_oranges"`
+	CSSBlues                 string    `json:"css_blues"`
+	CSSReds                  string    `json:"css_reds"`
+	CSSBorderColor           string    `json:"css_border_color"`
+	CSSGraphColor            string    `json:"css_graph_color"`
+	CSSLinkColor             string    `json:"css_link_color"`
+	CSSNoData                string    `json:"css_no_data"`
+}
+
+// PageParams are the fields sent when updating a page. Nil fields are left out of the request
+// so an update only changes the fields that are set.
+type PageParams struct {
+	Name                     *string `json:"name,omitempty"`
+	Domain                   *string `json:"domain,omitempty"`
+	Subdomain                *string `json:"subdomain,omitempty"`
+	URL                      *string `json:"url,omitempty"`
+	Branding                 *string `json:"branding,omitempty"`
+	SupportURL               *string `json:"support_url,omitempty"`
+	TimeZone                 *string `json:"time_zone,omitempty"`
+	AllowPageSubscribers     *bool   `json:"allow_page_subscribers,omitempty"`
+	AllowIncidentSubscribers *bool   `json:"allow_incident_subscribers,omitempty"`
+	AllowEmailSubscribers    *bool   `json:"allow_email_subscribers,omitempty"`
+	AllowSMSSubscribers      *bool   `json:"allow_sms_subscribers,omitempty"`
+	AllowRSSAtomFeeds        *bool   `json:"allow_rss_atom_feeds,omitempty"`
+	AllowWebhookSubscribers  *bool   `json:"allow_webhook_subscribers,omitempty"`
+	HiddenFromSearch         *bool   `json:"hidden_from_search,omitempty"`
+	ViewersMustBeTeamMembers *bool   `json:"viewers_must_be_team_members,omitempty"`
+	NotificationsFromEmail   *string `json:"notifications_from_email,omitempty"`
+	NotificationsEmailFooter *string `json:"notifications_email_footer,omitempty"`
+	CSSBodyBackgroundColor   *string `json:"css_body_background_color,omitempty"`
+	CSSFontColor             *string `json:"css_font_color,omitempty"`
+	CSSLightFontColor        *string `json:"css_light_font_color,omitempty"`
+	CSSGreens                *string `json:"css_greens,omitempty"`
+	CSSYellows               *string `json:"css_yellows,omitempty"`
+	CSSOranges               *string `json:"css_oranges,omitempty"`
+	CSSBlues                 *string `json:"css_blues,omitempty"`
+	CSSReds                  *string `json:"css_reds,omitempty"`
+	CSSBorderColor           *string `json:"css_border_color,omitempty"`
+	CSSGraphColor            *string `json:"css_graph_color,omitempty"`
+	CSSLinkColor             *string `json:"css_link_color,omitempty"`
+	CSSNoData                *string `json:"css_no_data,omitempty"`
+}
+
+type pageRequest struct {
+	Page PageParams `json:"page"`
+}
+
+// ListPages returns the pages the API key has access to.
+func (c *Client) ListPages() ([]Page, error) {
+	var pages []Page
+	if err := c.do("GET", "/pages", nil, &pages); err != nil {
+		return nil, err
+	}
+	return pages, nil
+}
+
+// GetPage returns a single page.
+func (c *Client) GetPage(pageID string) (*Page, error) {
+	page := &Page{}
+	if err := c.do("GET", "/pages/"+pageID, nil, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+// UpdatePage updates the settings of a page.
+func (c *Client) UpdatePage(pageID string, params PageParams) (*Page, error) {
+	page := &Page{}
+	if err := c.do("PATCH", "/pages/"+pageID, pageRequest{params}, page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
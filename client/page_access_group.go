@@ -0,0 +1,115 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// PageAccessGroup is a group of page access users who can see the same components and metrics of
+// an audience-specific page.
+type PageAccessGroup struct {
+	ID                 string    `json:"id"`
+	PageID             string    `json:"page_id"`
+	Name               string    `json:"name"`
+	ExternalIdentifier string    `json:"external_identifier"`
+	PageAccessUserIDs  []string  `json:"page_access_user_ids"`
+	ComponentIDs       []string  `json:"component_ids"`
+	MetricIDs          []string  `json:"metric_ids"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// PageAccessGroupParams are the fields sent when creating or updating a page access group. Nil
+// fields are left out of the request. ComponentIDs and MetricIDs replace the components and metrics
+// the group can see, and point to an empty list to remove them all.
+type PageAccessGroupParams struct {
+	Name               *string   `json:"name,omitempty"`
+	ExternalIdentifier *string   `json:"external_identifier,omitempty"`
+	ComponentIDs       *[]string `json:"component_ids,omitempty"`
+	MetricIDs          *[]string `json:"metric_ids,omitempty"`
+}
+
+type pageAccessGroupRequest struct {
+	PageAccessGroup PageAccessGroupParams `json:"page_access_group"`
+}
+
+type pageAccessGroupComponentsRequest struct {
+	ComponentIDs []string `json:"component_ids"`
+}
+
+// ListPageAccessGroups returns the page access groups of a page selected by opts.
+func (c *Client) ListPageAccessGroups(pageID string, opts *ListOptions) ([]PageAccessGroup, error) {
+	return listAll[PageAccessGroup](c, pagePath(pageID, "page_access_groups"), "per_page", opts)
+}
+
+// EachPageAccessGroups calls fn with every page of page access groups selected by opts as it is fetched.
+func (c *Client) EachPageAccessGroups(pageID string, opts *ListOptions, fn func([]PageAccessGroup) error) error {
+	return eachPage(c, pagePath(pageID, "page_access_groups"), "per_page", opts, fn)
+}
+
+// GetPageAccessGroup returns a single page access group of a page.
+func (c *Client) GetPageAccessGroup(pageID, groupID string) (*PageAccessGroup, error) {
+	group := &PageAccessGroup{}
+	if err := c.do("GET", pagePath(pageID, "page_access_groups", groupID), nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// CreatePageAccessGroup creates a page access group on a page.
+func (c *Client) CreatePageAccessGroup(pageID string, params PageAccessGroupParams) (*PageAccessGroup, error) {
+	group := &PageAccessGroup{}
+	if err := c.do("POST", pagePath(pageID, "page_access_groups"), pageAccessGroupRequest{params}, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// UpdatePageAccessGroup updates a page access group of a page.
+func (c *Client) UpdatePageAccessGroup(pageID, groupID string, params PageAccessGroupParams) (*PageAccessGroup, error) {
+	group := &PageAccessGroup{}
+	if err := c.do("PATCH", pagePath(pageID, "page_access_groups", groupID), pageAccessGroupRequest{params}, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// DeletePageAccessGroup deletes a page access group of a page and returns it. Its users are kept.
+func (c *Client) DeletePageAccessGroup(pageID, groupID string) (*PageAccessGroup, error) {
+	group := &PageAccessGroup{}
+	if err := c.do("DELETE", pagePath(pageID, "page_access_groups", groupID), nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// AddPageAccessGroupComponents lets a page access group see more components, keeping the
+// components it already sees.
+func (c *Client) AddPageAccessGroupComponents(pageID, groupID string, componentIDs []string) (*PageAccessGroup, error) {
+	group := &PageAccessGroup{}
+	if err := c.do("PATCH", pagePath(pageID, "page_access_groups", groupID, "components"), pageAccessGroupComponentsRequest{componentIDs}, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
+
+// RemovePageAccessGroupComponent stops a page access group from seeing a component.
+func (c *Client) RemovePageAccessGroupComponent(pageID, groupID, componentID string) (*PageAccessGroup, error) {
+	group := &PageAccessGroup{}
+	if err := c.do("DELETE", pagePath(pageID, "page_access_groups", groupID, "components", componentID), nil, group); err != nil {
+		return nil, err
+	}
+	return group, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"net/url"
+	"time"
+)
+
+// PageAccessUser is someone allowed to see a private page, or the parts of an audience-specific
+// page granted to their page access groups.
+type PageAccessUser struct {
+	ID                 string    `json:"id"`
+	PageID             string    `json:"page_id"`
+	Email              string    `json:"email"`
+	ExternalLogin      string    `json:"external_login"`
+	PageAccessGroupIDs []string  `json:"page_access_group_ids"`
+	CreatedAt          time.Time `json:"created_at"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
+// PageAccessUserParams are the fields sent when creating or updating a page access user. Nil
+// fields are left out of the request. PageAccessGroupIDs replaces the groups of the user, and
+// points to an empty list to remove them all.
+type PageAccessUserParams struct {
+	Email              *string   `json:"email,omitempty"`
+	ExternalLogin      *string   `json:"external_login,omitempty"`
+	PageAccessGroupIDs *[]string `json:"page_access_group_ids,omitempty"`
+}
+
+type pageAccessUserRequest struct {
+	PageAccessUser PageAccessUserParams `json:"page_access_user"`
+}
+
+// ListPageAccessUsers returns the page access users of a page selected by opts.
+func (c *Client) ListPageAccessUsers(pageID string, opts *ListOptions) ([]PageAccessUser, error) {
+	return listAll[PageAccessUser](c, pagePath(pageID, "page_access_users"), "per_page", opts)
+}
+
+// EachPageAccessUsers calls fn with every page of page access users selected by opts as it is fetched.
+func (c *Client) EachPageAccessUsers(pageID string, opts *ListOptions, fn func([]PageAccessUser) error) error {
+	return eachPage(c, pagePath(pageID, "page_access_users"), "per_page", opts, fn)
+}
+
+// FindPageAccessUsers returns the page access users of a page with the given email address.
+func (c *Client) FindPageAccessUsers(pageID, email string) ([]PageAccessUser, error) {
+	return c.ListPageAccessUsers(pageID, &ListOptions{All: true, Query: url.Values{"email": {email}}})
+}
+
+// GetPageAccessUser returns a single page access user of a page.
+func (c *Client) GetPageAccessUser(pageID, userID string) (*PageAccessUser, error) {
+	user := &PageAccessUser{}
+	if err := c.do("GET", pagePath(pageID, "page_access_users", userID), nil, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// CreatePageAccessUser gives someone access to a page.
+func (c *Client) CreatePageAccessUser(pageID string, params PageAccessUserParams) (*PageAccessUser, error) {
+	user := &PageAccessUser{}
+	if err := c.do("POST", pagePath(pageID, "page_access_users"), pageAccessUserRequest{params}, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdatePageAccessUser updates a page access user of a page.
+func (c *Client) UpdatePageAccessUser(pageID, userID string, params PageAccessUserParams) (*PageAccessUser, error) {
+	user := &PageAccessUser{}
+	if err := c.do("PATCH", pagePath(pageID, "page_access_users", userID), pageAccessUserRequest{params}, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeletePageAccessUser removes the access of a page access user to a page.
+func (c *Client) DeletePageAccessUser(pageID, userID string) error {
+	return c.do("DELETE", pagePath(pageID, "page_access_users", userID), nil, nil)
+}
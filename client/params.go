@@ -0,0 +1,26 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+// String returns a pointer to s, for setting optional string fields of request parameters.
+func String(s string) *string {
+	return &s
+}
+
+// Bool returns a pointer to b, for setting optional bool fields of request parameters.
+func Bool(b bool) *bool {
+	return &b
+}
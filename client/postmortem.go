@@ -0,0 +1,89 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import "time"
+
+// Postmortem is the postmortem of an incident. The draft is edited until it is published as the body.
+type Postmortem struct {
+	PreviewKey         string     `json:"preview_key"`
+	Body               string     `json:"body"`
+	BodyUpdatedAt      *time.Time `json:"body_updated_at"`
+	BodyDraft          string     `json:"body_draft"`
+	BodyDraftUpdatedAt *time.Time `json:"body_draft_updated_at"`
+	PublishedAt        *time.Time `json:"published_at"`
+	NotifySubscribers  bool       `json:"notify_subscribers"`
+	NotifyTwitter      bool       `json:"notify_twitter"`
+	CustomTweet        string     `json:"custom_tweet"`
+}
+
+// PublishPostmortemParams are the fields sent when publishing a postmortem. Nil fields are left
+// out of the request.
+type PublishPostmortemParams struct {
+	NotifySubscribers *bool   `json:"notify_subscribers,omitempty"`
+	NotifyTwitter     *bool   `json:"notify_twitter,omitempty"`
+	CustomTweet       *string `json:"custom_tweet,omitempty"`
+}
+
+type postmortemDraftRequest struct {
+	Postmortem struct {
+		BodyDraft string `json:"body_draft"`
+	} `json:"postmortem"`
+}
+
+type publishPostmortemRequest struct {
+	Postmortem PublishPostmortemParams `json:"postmortem"`
+}
+
+// GetPostmortem returns the postmortem of an incident.
+func (c *Client) GetPostmortem(pageID, incidentID string) (*Postmortem, error) {
+	postmortem := &Postmortem{}
+	if err := c.do("GET", pagePath(pageID, "incidents", incidentID, "postmortem"), nil, postmortem); err != nil {
+		return nil, err
+	}
+	return postmortem, nil
+}
+
+// UpdatePostmortem replaces the draft of the postmortem of an incident. The published postmortem
+// is left as it is until the draft is published.
+func (c *Client) UpdatePostmortem(pageID, incidentID, bodyDraft string) (*Postmortem, error) {
+	request := postmortemDraftRequest{}
+	request.Postmortem.BodyDraft = bodyDraft
+
+	postmortem := &Postmortem{}
+	if err := c.do("PUT", pagePath(pageID, "incidents", incidentID, "postmortem"), request, postmortem); err != nil {
+		return nil, err
+	}
+	return postmortem, nil
+}
+
+// PublishPostmortem publishes the draft of the postmortem of an incident.
+func (c *Client) PublishPostmortem(pageID, incidentID string, params PublishPostmortemParams) (*Postmortem, error) {
+	postmortem := &Postmortem{}
+	if err := c.do("PUT", pagePath(pageID, "incidents", incidentID, "postmortem", "publish"), publishPostmortemRequest{params}, postmortem); err != nil {
+		return nil, err
+	}
+	return postmortem, nil
+}
+
+// RevertPostmortem unpublishes the postmortem of an incident, turning it back into a draft.
+func (c *Client) RevertPostmortem(pageID, incidentID string) (*Postmortem, error) {
+	postmortem := &Postmortem{}
+	if err := c.do("PUT", pagePath(pageID, "incidents", incidentID, "postmortem", "revert"), nil, postmortem); err != nil {
+		return nil, err
+	}
+	return postmortem, nil
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// SubscriberTypes are the types of subscribers, as used to filter them.
+var SubscriberTypes = []string{"email", "sms", "webhook", "slack", "integration_partner"}
+
+// SubscriberStates are the states of subscribers, as used to filter them.
+var SubscriberStates = []string{"active", "unconfirmed", "quarantined", "all"}
+
+// Subscriber is someone notified of the incidents of a page, by email, SMS, webhook or Slack.
+type Subscriber struct {
+	ID                           string     `json:"id"`
+	Mode                         string     `json:"mode"`
+	Email                        string     `json:"email"`
+	Endpoint                     string     `json:"endpoint"`
+	PhoneCountry                 string     `json:"phone_country"`
+	PhoneNumber                  string     `json:"phone_number"`
+	DisplayPhoneNumber           string     `json:"display_phone_number"`
+	ObfuscatedChannelName        string     `json:"obfuscated_channel_name"`
+	WorkspaceName                string     `json:"workspace_name"`
+	SkipConfirmationNotification bool       `json:"skip_confirmation_notification"`
+	Components                   []string   `json:"components"`
+	PageAccessUserID             string     `json:"page_access_user_id"`
+	QuarantinedAt                *time.Time `json:"quarantined_at"`
+	PurgeAt                      *time.Time `json:"purge_at"`
+	CreatedAt                    time.Time  `json:"created_at"`
+}
+
+// SubscriberParams are the fields sent when creating a subscriber. Nil and empty fields are left
+// out of the request. Subscribers without components are notified of every component.
+type SubscriberParams struct {
+	Email                        *string  `json:"email,omitempty"`
+	Endpoint                     *string  `json:"endpoint,omitempty"`
+	PhoneCountry                 *string  `json:"phone_country,omitempty"`
+	PhoneNumber                  *string  `json:"phone_number,omitempty"`
+	SkipConfirmationNotification *bool    `json:"skip_confirmation_notification,omitempty"`
+	ComponentIDs                 []string `json:"component_ids,omitempty"`
+}
+
+type subscriberRequest struct {
+	Subscriber SubscriberParams `json:"subscriber"`
+}
+
+// ListSubscribers returns the subscribers of a page selected by opts. Subscribers are filtered
+// with the "type", "state" and "q" query parameters of opts.
+func (c *Client) ListSubscribers(pageID string, opts *ListOptions) ([]Subscriber, error) {
+	return listAll[Subscriber](c, pagePath(pageID, "subscribers"), "limit", opts)
+}
+
+// EachSubscribers calls fn with every page of subscribers selected by opts as it is fetched.
+func (c *Client) EachSubscribers(pageID string, opts *ListOptions, fn func([]Subscriber) error) error {
+	return eachPage(c, pagePath(pageID, "subscribers"), "limit", opts, fn)
+}
+
+// GetSubscriber returns a single subscriber of a page.
+func (c *Client) GetSubscriber(pageID, subscriberID string) (*Subscriber, error) {
+	subscriber := &Subscriber{}
+	if err := c.do("GET", pagePath(pageID, "subscribers", subscriberID), nil, subscriber); err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
+// CreateSubscriber subscribes someone to a page. Unless SkipConfirmationNotification is set,
+// email and SMS subscribers are sent a confirmation they have to accept.
+func (c *Client) CreateSubscriber(pageID string, params SubscriberParams) (*Subscriber, error) {
+	subscriber := &Subscriber{}
+	if err := c.do("POST", pagePath(pageID, "subscribers"), subscriberRequest{params}, subscriber); err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
+// DeleteSubscriber unsubscribes a subscriber of a page and returns it. The subscriber is told
+// unless skipNotification is set.
+func (c *Client) DeleteSubscriber(pageID, subscriberID string, skipNotification bool) (*Subscriber, error) {
+	path := pagePath(pageID, "subscribers", subscriberID)
+	if skipNotification {
+		path += "?skip_unsubscription_notification=true"
+	}
+
+	subscriber := &Subscriber{}
+	if err := c.do("DELETE", path, nil, subscriber); err != nil {
+		return nil, err
+	}
+	return subscriber, nil
+}
+
+// ResendConfirmation sends the confirmation of an unconfirmed subscriber again.
+func (c *Client) ResendConfirmation(pageID, subscriberID string) error {
+	return c.do("POST", pagePath(pageID, "subscribers", subscriberID, "resend_confirmation"), nil, nil)
+}
+
+// CountSubscribers returns the number of subscribers of a page.
+func (c *Client) CountSubscribers(pageID string) (int, error) {
+	var raw json.RawMessage
+	if err := c.do("GET", pagePath(pageID, "subscribers", "count"), nil, &raw); err != nil {
+		return 0, err
+	}
+
+	// The count is sent either on its own or as the count field of an object.
+	var count int
+	if err := json.Unmarshal(raw, &count); err == nil {
+		return count, nil
+	}
+	var body struct {
+		Count int `json:"count"`
+	}
+	if err := json.Unmarshal(raw, &body); err != nil {
+		return 0, err
+	}
+	return body.Count, nil
+}
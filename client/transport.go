@@ -0,0 +1,215 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"context"
+	"golang.org/x/time/rate"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// DefaultMaxRetries is the number of times a failed request is retried.
+	DefaultMaxRetries = 3
+	// DefaultTimeout is the time allowed for each attempt of a request.
+	DefaultTimeout = time.Second * 10
+	// DefaultRateLimit is the number of requests per second allowed by Statuspage for an API key.
+	DefaultRateLimit = 1
+	// DefaultRateBurst is the number of requests that can be sent at once before the rate limit applies.
+	DefaultRateBurst = 10
+)
+
+// RetryTransport is an http.RoundTripper that throttles requests with a token bucket and
+// retries rate limited and failed requests with exponential backoff and jitter.
+type RetryTransport struct {
+	// Base sends the requests, http.DefaultTransport when nil.
+	Base http.RoundTripper
+	// MaxRetries is the number of times a request is retried after the first attempt.
+	MaxRetries int
+	// Timeout bounds each attempt of a request, no timeout when zero.
+	Timeout time.Duration
+	// MinBackoff is the wait before the first retry, doubled on every following retry.
+	MinBackoff time.Duration
+	// MaxBackoff caps the wait between retries, including waits asked for with Retry-After.
+	MaxBackoff time.Duration
+	// Limiter throttles requests before they are sent, no throttling when nil.
+	Limiter *rate.Limiter
+}
+
+// NewRetryTransport returns a RetryTransport using the Statuspage rate limit and the given
+// number of retries and per attempt timeout.
+func NewRetryTransport(maxRetries int, timeout time.Duration) *RetryTransport {
+	return &RetryTransport{
+		MaxRetries: maxRetries,
+		Timeout:    timeout,
+		MinBackoff: time.Second,
+		MaxBackoff: time.Minute,
+		Limiter:    rate.NewLimiter(DefaultRateLimit, DefaultRateBurst),
+	}
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	// Attempts send copies of the body, but the RoundTripper contract still requires closing it.
+	if req.Body != nil {
+		defer req.Body.Close()
+	}
+	// A body that cannot be copied can only be sent once.
+	replayable := req.Body == nil || req.GetBody != nil
+
+	for attempt := 0; ; attempt++ {
+		if t.Limiter != nil {
+			if err := t.Limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+
+		resp, err := t.send(req)
+		if attempt >= t.MaxRetries || !replayable || !shouldRetry(req, resp, err) {
+			return resp, err
+		}
+
+		wait := t.backoff(attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		select {
+		case <-time.After(wait):
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		}
+	}
+}
+
+// send makes a single attempt of req, with a fresh copy of its body and the attempt timeout.
+func (t *RetryTransport) send(req *http.Request) (*http.Response, error) {
+	ctx, cancel := context.WithCancel(req.Context())
+	if t.Timeout > 0 {
+		cancel()
+		ctx, cancel = context.WithTimeout(req.Context(), t.Timeout)
+	}
+
+	attempt := req.Clone(ctx)
+	if req.Body != nil && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			cancel()
+			return nil, err
+		}
+		attempt.Body = body
+	}
+
+	base := t.Base
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	resp, err := base.RoundTrip(attempt)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	resp.Body = &cancelOnClose{ReadCloser: resp.Body, cancel: cancel}
+	return resp, nil
+}
+
+// backoff returns how long to wait before retrying, honouring Retry-After when the API sets it.
+func (t *RetryTransport) backoff(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if wait, ok := retryAfter(resp.Header.Get("Retry-After")); ok {
+			if wait > t.MaxBackoff {
+				return t.MaxBackoff
+			}
+			return wait
+		}
+	}
+
+	wait := t.MinBackoff << uint(attempt)
+	if wait <= 0 || wait > t.MaxBackoff {
+		wait = t.MaxBackoff
+	}
+	// Equal jitter: wait between half and all of the backoff so concurrent clients spread out.
+	half := int64(wait / 2)
+	if half <= 0 {
+		return wait
+	}
+	return time.Duration(half + rand.Int63n(half))
+}
+
+// shouldRetry reports whether a request is worth sending again. Throttled requests were not
+// processed so they are always retried; server and network errors are only retried for requests
+// that only read, as a POST, PATCH, PUT or DELETE may already have been processed and sent
+// notifications, such as a published postmortem or an incident update.
+func shouldRetry(req *http.Request, resp *http.Response, err error) bool {
+	if req.Context().Err() != nil {
+		return false
+	}
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS":
+	default:
+		return false
+	}
+
+	if err != nil {
+		return true
+	}
+	switch resp.StatusCode {
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses a Retry-After header given either in seconds or as an HTTP date.
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
+
+// cancelOnClose releases the context of an attempt once its response body has been read.
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (b *cancelOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.cancel()
+	return err
+}
@@ -0,0 +1,224 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestShouldRetry(t *testing.T) {
+	networkError := errors.New("connection reset")
+
+	tests := []struct {
+		method string
+		status int
+		err    error
+		want   bool
+	}{
+		{"GET", http.StatusOK, nil, false},
+		{"GET", http.StatusNotFound, nil, false},
+		{"GET", http.StatusTooManyRequests, nil, true},
+		{"GET", http.StatusInternalServerError, nil, true},
+		{"GET", http.StatusBadGateway, nil, true},
+		{"GET", http.StatusServiceUnavailable, nil, true},
+		{"GET", http.StatusGatewayTimeout, nil, true},
+		{"GET", http.StatusNotImplemented, nil, false},
+		{"GET", 0, networkError, true},
+		{"HEAD", http.StatusServiceUnavailable, nil, true},
+		{"OPTIONS", 0, networkError, true},
+		{"POST", http.StatusTooManyRequests, nil, true},
+		{"POST", http.StatusServiceUnavailable, nil, false},
+		{"POST", 0, networkError, false},
+		{"PATCH", http.StatusTooManyRequests, nil, true},
+		{"PATCH", http.StatusBadGateway, nil, false},
+		{"PUT", http.StatusTooManyRequests, nil, true},
+		{"PUT", http.StatusServiceUnavailable, nil, false},
+		{"PUT", 0, networkError, false},
+		{"DELETE", http.StatusTooManyRequests, nil, true},
+		{"DELETE", http.StatusInternalServerError, nil, false},
+	}
+
+	for _, test := range tests {
+		req := httptest.NewRequest(test.method, "http://example.com/", nil)
+		var resp *http.Response
+		if test.err == nil {
+			resp = &http.Response{StatusCode: test.status}
+		}
+		if got := shouldRetry(req, resp, test.err); got != test.want {
+			t.Errorf("shouldRetry(%s, %d, %v) = %v, want %v", test.method, test.status, test.err, got, test.want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	tests := []struct {
+		value string
+		want  time.Duration
+		ok    bool
+	}{
+		{"", 0, false},
+		{"0", 0, true},
+		{"5", 5 * time.Second, true},
+		{"-1", 0, false},
+		{"soon", 0, false},
+		{"Mon, 01 Jan 2001 00:00:00 GMT", 0, true},
+	}
+
+	for _, test := range tests {
+		got, ok := retryAfter(test.value)
+		if got != test.want || ok != test.ok {
+			t.Errorf("retryAfter(%q) = %v, %v, want %v, %v", test.value, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	transport := &RetryTransport{MinBackoff: time.Second, MaxBackoff: 10 * time.Second}
+
+	tests := []struct {
+		attempt  int
+		min, max time.Duration
+	}{
+		{0, 500 * time.Millisecond, time.Second},
+		{1, time.Second, 2 * time.Second},
+		{2, 2 * time.Second, 4 * time.Second},
+		{3, 4 * time.Second, 8 * time.Second},
+		{4, 5 * time.Second, 10 * time.Second},
+		{40, 5 * time.Second, 10 * time.Second},
+	}
+
+	for _, test := range tests {
+		for i := 0; i < 20; i++ {
+			if got := transport.backoff(test.attempt, nil); got < test.min || got > test.max {
+				t.Errorf("backoff(%d) = %v, want between %v and %v", test.attempt, got, test.min, test.max)
+			}
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"3"}}}
+	if got := transport.backoff(0, resp); got != 3*time.Second {
+		t.Errorf("backoff with Retry-After: 3 = %v, want 3s", got)
+	}
+	resp.Header.Set("Retry-After", "3600")
+	if got := transport.backoff(0, resp); got != 10*time.Second {
+		t.Errorf("backoff with Retry-After: 3600 = %v, want the maximum of 10s", got)
+	}
+}
+
+// closeTracker records whether a request body was closed.
+type closeTracker struct {
+	*strings.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+func TestRoundTrip(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		statuses []int
+		want     int
+		attempts int32
+	}{
+		{"success", "GET", []int{200}, 200, 1},
+		{"GET retried after a server error", "GET", []int{503, 502, 200}, 200, 3},
+		{"GET gives up after the retries", "GET", []int{500, 500, 500, 500, 500}, 500, 4},
+		{"POST retried when rate limited", "POST", []int{429, 201}, 201, 2},
+		{"POST not retried after a server error", "POST", []int{503, 201}, 503, 1},
+		{"PUT not retried after a server error", "PUT", []int{504, 200}, 504, 1},
+		{"PATCH not retried after a server error", "PATCH", []int{500, 200}, 500, 1},
+	}
+
+	for _, test := range tests {
+		var attempts int32
+		var bodies []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&attempts, 1)
+			body, _ := ioutil.ReadAll(r.Body)
+			bodies = append(bodies, string(body))
+			w.WriteHeader(test.statuses[n-1])
+		}))
+
+		transport := &RetryTransport{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+		body := &closeTracker{Reader: strings.NewReader(`{"a":1}`)}
+		req, err := http.NewRequest(test.method, server.URL, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(strings.NewReader(`{"a":1}`)), nil
+		}
+
+		resp, err := transport.RoundTrip(req)
+		server.Close()
+		if err != nil {
+			t.Errorf("%s: RoundTrip returned error: %v", test.name, err)
+			continue
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != test.want {
+			t.Errorf("%s: status %d, want %d", test.name, resp.StatusCode, test.want)
+		}
+		if attempts != test.attempts {
+			t.Errorf("%s: %d attempts, want %d", test.name, attempts, test.attempts)
+		}
+		for i, b := range bodies {
+			if b != `{"a":1}` {
+				t.Errorf("%s: attempt %d sent body %q", test.name, i+1, b)
+			}
+		}
+		if !body.closed {
+			t.Errorf("%s: request body not closed", test.name)
+		}
+	}
+}
+
+func TestRoundTripBodyWithoutGetBody(t *testing.T) {
+	var attempts int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	transport := &RetryTransport{MaxRetries: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	req, err := http.NewRequest("POST", server.URL, ioutil.NopCloser(strings.NewReader("x")))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	if attempts != 1 {
+		t.Errorf("%d attempts of a request whose body cannot be copied, want 1", attempts)
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+// User is a team member of a Statuspage organization.
+type User struct {
+	ID             string    `json:"id"`
+	OrganizationID string    `json:"organization_id"`
+	Email          string    `json:"email"`
+	FirstName      string    `json:"first_name"`
+	LastName       string    `json:"last_name"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+}
+
+// UserParams are the fields sent when creating a user. Nil fields are left out of the request.
+type UserParams struct {
+	Email     *string `json:"email,omitempty"`
+	FirstName *string `json:"first_name,omitempty"`
+	LastName  *string `json:"last_name,omitempty"`
+}
+
+type userRequest struct {
+	User UserParams `json:"user"`
+}
+
+// PagePermissions are the roles of a user on one page.
+type PagePermissions struct {
+	PageID             string `json:"page_id"`
+	PageConfiguration  bool   `json:"page_configuration"`
+	IncidentManager    bool   `json:"incident_manager"`
+	MaintenanceManager bool   `json:"maintenance_manager"`
+}
+
+// Permissions are the roles of a user on the pages of an organization.
+type Permissions struct {
+	UserID string            `json:"user_id"`
+	Pages  []PagePermissions `json:"pages"`
+}
+
+type permissionsResponse struct {
+	Data Permissions `json:"data"`
+}
+
+type pageRoles struct {
+	PageConfiguration  bool `json:"page_configuration"`
+	IncidentManager    bool `json:"incident_manager"`
+	MaintenanceManager bool `json:"maintenance_manager"`
+}
+
+type permissionsRequest struct {
+	Pages map[string]pageRoles `json:"pages"`
+}
+
+func organizationPath(organizationID string, elem ...string) string {
+	return "/organizations/" + organizationID + "/" + strings.Join(elem, "/")
+}
+
+// ListUsers returns the users of an organization selected by opts.
+func (c *Client) ListUsers(organizationID string, opts *ListOptions) ([]User, error) {
+	return listAll[User](c, organizationPath(organizationID, "users"), "per_page", opts)
+}
+
+// EachUsers calls fn with every page of users of an organization selected by opts as it is fetched.
+func (c *Client) EachUsers(organizationID string, opts *ListOptions, fn func([]User) error) error {
+	return eachPage(c, organizationPath(organizationID, "users"), "per_page", opts, fn)
+}
+
+// CreateUser adds a user to an organization. Statuspage emails them an invitation to set their password.
+func (c *Client) CreateUser(organizationID string, params UserParams) (*User, error) {
+	user := &User{}
+	if err := c.do("POST", organizationPath(organizationID, "users"), userRequest{params}, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// DeleteUser removes a user from an organization and returns it.
+func (c *Client) DeleteUser(organizationID, userID string) (*User, error) {
+	user := &User{}
+	if err := c.do("DELETE", organizationPath(organizationID, "users", userID), nil, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetPermissions returns the roles of a user on the pages of an organization.
+func (c *Client) GetPermissions(organizationID, userID string) (*Permissions, error) {
+	resp := &permissionsResponse{}
+	if err := c.do("GET", organizationPath(organizationID, "permissions", userID), nil, resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
+
+// SetPermissions replaces the roles of a user on the pages of an organization with pages. The user
+// loses access to the pages left out.
+func (c *Client) SetPermissions(organizationID, userID string, pages []PagePermissions) (*Permissions, error) {
+	req := permissionsRequest{Pages: map[string]pageRoles{}}
+	for _, p := range pages {
+		req.Pages[p.PageID] = pageRoles{p.PageConfiguration, p.IncidentManager, p.MaintenanceManager}
+	}
+
+	resp := &permissionsResponse{}
+	if err := c.do("PUT", organizationPath(organizationID, "permissions", userID), req, resp); err != nil {
+		return nil, err
+	}
+	return &resp.Data, nil
+}
@@ -0,0 +1,289 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../manifest"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var manifestFile string
+var applyPrune bool
+var applyDryRun bool
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create and update the components, component groups, metrics, incident templates and settings of a page to match a manifest.",
+	Long: `Create and update the components and component groups of a page to match a YAML or JSON manifest.
+Components and groups are matched to the page by name. Components and groups of the page missing from
+the manifest are deleted when --prune is set.
+
+Manifests can also declare the page settings under page, metrics under metrics and incident templates
+under incident_templates, as written by export. Metrics are created with the metrics provider of the
+page of their provider type, Self when unset, and deleted with --prune when the manifest declares
+metrics. Incident templates missing from the page are created. The API documents no way to update
+templates, so the templates already on the page are left as they are and not reported by diff.
+
+Example manifest:
+
+  groups:
+    - name: Europe
+      description: Services hosted in eu-west-1
+  components:
+    - name: API Gateway
+      description: Public REST API
+      group: Europe
+      showcase: true
+    - name: Website
+      only_show_if_degraded: false
+      start_date: "2020-01-01"`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		m, err := manifest.Load(manifestFile)
+		exitOnError(err)
+
+		page, err := fetchManifestPage(c, m)
+		exitOnError(err)
+
+		changes, err := manifest.Plan(m, page, applyPrune)
+		exitOnError(err)
+
+		if len(changes) == 0 {
+			fmt.Println("The page matches the manifest, there is nothing to change.")
+			return
+		}
+
+		if applyDryRun {
+			manifest.WriteDiff(os.Stdout, changes, colorOutput(false))
+			return
+		}
+
+		exitOnError(applyChanges(c, m, page, changes))
+	},
+}
+
+// fetchManifestPage returns the state of the page compared with m: its components, component groups
+// and metrics, and its settings and incident templates when m declares them. Everything is fetched
+// when m is nil, as for exports.
+func fetchManifestPage(c *client.Client, m *manifest.Manifest) (*manifest.Page, error) {
+	page := &manifest.Page{}
+	var err error
+
+	if page.Components, err = c.ListComponents(pageID, &client.ListOptions{All: true}); err != nil {
+		return nil, err
+	}
+	if page.Groups, err = c.ListComponentGroups(pageID, &client.ListOptions{All: true}); err != nil {
+		return nil, err
+	}
+	if page.Metrics, err = c.ListMetrics(pageID, &client.ListOptions{All: true}); err != nil {
+		return nil, err
+	}
+	if m == nil || len(m.Metrics) > 0 {
+		if page.Providers, err = c.ListMetricsProviders(pageID, &client.ListOptions{All: true}); err != nil {
+			return nil, err
+		}
+	}
+	if m == nil || m.Page != nil {
+		if page.Settings, err = c.GetPage(pageID); err != nil {
+			return nil, err
+		}
+	}
+	if m == nil || len(m.IncidentTemplates) > 0 {
+		if page.IncidentTemplates, err = c.ListIncidentTemplates(pageID, &client.ListOptions{All: true}); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// applyChanges makes the changes planned for a manifest, printing each change once it is made.
+func applyChanges(c *client.Client, m *manifest.Manifest, page *manifest.Page, changes []manifest.Change) error {
+	// Groups refer to their components by identifier, including the components created on the way.
+	componentIDs := map[string]string{}
+	for _, component := range page.Components {
+		if !component.Group {
+			componentIDs[component.Name] = component.ID
+		}
+	}
+
+	for _, change := range changes {
+		switch {
+		case change.Kind == manifest.KindPage:
+			params, err := manifest.PageParams(m.Page, change.Fields)
+			if err != nil {
+				return err
+			}
+			if _, err := c.UpdatePage(pageID, params); err != nil {
+				return fmt.Errorf("error updating the page settings: %v", err)
+			}
+		case change.Kind == manifest.KindComponent && change.Action == manifest.Create:
+			want, _ := m.Component(change.Name)
+			component, err := c.CreateComponent(pageID, componentParams(want))
+			if err != nil {
+				return fmt.Errorf("error creating component %q: %v", change.Name, err)
+			}
+			componentIDs[change.Name] = component.ID
+		case change.Kind == manifest.KindComponent && change.Action == manifest.Update:
+			want, _ := m.Component(change.Name)
+			if _, err := c.UpdateComponent(pageID, change.ID, componentParams(want)); err != nil {
+				return fmt.Errorf("error updating component %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindComponent && change.Action == manifest.Delete:
+			if err := c.DeleteComponent(pageID, change.ID); err != nil {
+				return fmt.Errorf("error deleting component %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindGroup && change.Action == manifest.Create:
+			want := m.Group(change.Name)
+			params := client.ComponentGroupParams{Name: &want.Name, Components: memberIDs(m, change.Name, componentIDs)}
+			if _, err := c.CreateComponentGroup(pageID, want.Description, params); err != nil {
+				return fmt.Errorf("error creating component group %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindGroup && change.Action == manifest.Update:
+			want := m.Group(change.Name)
+			params := client.ComponentGroupParams{Components: memberIDs(m, change.Name, componentIDs)}
+			if _, err := c.UpdateComponentGroup(pageID, change.ID, want.Description, params); err != nil {
+				return fmt.Errorf("error updating component group %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindGroup && change.Action == manifest.Delete:
+			if _, err := c.DeleteComponentGroup(pageID, change.ID); err != nil {
+				return fmt.Errorf("error deleting component group %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindMetric && change.Action == manifest.Create:
+			want, _ := m.Metric(change.Name)
+			providerID, err := metricsProviderID(page, want)
+			if err != nil {
+				return err
+			}
+			params := metricParams(want)
+			if want.MetricIdentifier != "" {
+				params.MetricIdentifier = &want.MetricIdentifier
+			}
+			if _, err := c.CreateMetric(pageID, providerID, params); err != nil {
+				return fmt.Errorf("error creating metric %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindMetric && change.Action == manifest.Update:
+			want, _ := m.Metric(change.Name)
+			if _, err := c.UpdateMetric(pageID, change.ID, metricParams(want)); err != nil {
+				return fmt.Errorf("error updating metric %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindMetric && change.Action == manifest.Delete:
+			if _, err := c.DeleteMetric(pageID, change.ID); err != nil {
+				return fmt.Errorf("error deleting metric %q: %v", change.Name, err)
+			}
+		case change.Kind == manifest.KindTemplate && change.Action == manifest.Create:
+			want, _ := m.IncidentTemplate(change.Name)
+			params, err := templateParams(want, componentIDs)
+			if err != nil {
+				return err
+			}
+			if _, err := c.CreateIncidentTemplate(pageID, params); err != nil {
+				return fmt.Errorf("error creating incident template %q: %v", change.Name, err)
+			}
+		}
+		fmt.Printf("%s %q %sd\n", change.Kind, change.Name, change.Action)
+	}
+	return nil
+}
+
+// componentParams returns the settings of a component managed by a manifest. Settings left unset
+// in the manifest are not sent, so they keep their value on the page.
+func componentParams(want manifest.Component) client.ComponentParams {
+	return client.ComponentParams{
+		Name:               &want.Name,
+		Description:        want.Description,
+		Showcase:           want.Showcase,
+		OnlyShowIfDegraded: want.OnlyShowIfDegraded,
+		StartDate:          want.StartDate,
+	}
+}
+
+// metricParams returns the settings of a metric managed by a manifest. Settings left unset in the
+// manifest are not sent, so they keep their value on the page.
+func metricParams(want manifest.Metric) client.MetricParams {
+	return client.MetricParams{
+		Name:               &want.Name,
+		Suffix:             want.Suffix,
+		Display:            want.Display,
+		TooltipDescription: want.TooltipDescription,
+		DecimalPlaces:      want.DecimalPlaces,
+		YAxisMin:           want.YAxisMin,
+		YAxisMax:           want.YAxisMax,
+		YAxisHidden:        want.YAxisHidden,
+	}
+}
+
+// metricsProviderID returns the identifier of the metrics provider of the page creating a metric
+// of a manifest, matched by provider type.
+func metricsProviderID(page *manifest.Page, want manifest.Metric) (string, error) {
+	provider := want.Provider
+	if provider == "" {
+		provider = "Self"
+	}
+	for _, p := range page.Providers {
+		if strings.EqualFold(p.Type, provider) {
+			return p.ID, nil
+		}
+	}
+	return "", fmt.Errorf("error creating metric %q: the page has no %s metrics provider, add it in the web UI first", want.Name, provider)
+}
+
+// templateParams returns the incident template of a manifest as sent to create it, with its
+// components referred to by identifier.
+func templateParams(want manifest.IncidentTemplate, componentIDs map[string]string) (client.IncidentTemplateParams, error) {
+	params := client.IncidentTemplateParams{
+		Name:                    &want.Name,
+		Title:                   &want.Title,
+		Body:                    &want.Body,
+		ShouldTweet:             &want.ShouldTweet,
+		ShouldSendNotifications: &want.ShouldSendNotifications,
+	}
+	if want.UpdateStatus != "" {
+		params.UpdateStatus = &want.UpdateStatus
+	}
+	for _, name := range want.Components {
+		id, ok := componentIDs[name]
+		if !ok {
+			return params, fmt.Errorf("error creating incident template %q: the page has no component named %q", want.Name, name)
+		}
+		params.ComponentIDs = append(params.ComponentIDs, id)
+	}
+	return params, nil
+}
+
+// memberIDs returns the identifiers of the components of a group of a manifest.
+func memberIDs(m *manifest.Manifest, group string, componentIDs map[string]string) []string {
+	ids := []string{}
+	for _, name := range m.Members(group) {
+		ids = append(ids, componentIDs[name])
+	}
+	return ids
+}
+
+func init() {
+	applyCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	applyCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	applyCmd.Flags().StringVarP(&manifestFile, "filename", "f", "", "YAML or JSON manifest to apply, - to read it from stdin (required)")
+	applyCmd.Flags().BoolVar(&applyPrune, "prune", false, "Delete the components, component groups and metrics of the page that are not in the manifest, metrics only when the manifest declares some")
+	applyCmd.Flags().BoolVar(&applyDryRun, "dry-run", false, "Print the changes that would be made without making them")
+	applyCmd.MarkFlagRequired("page-id")
+	applyCmd.MarkFlagRequired("filename")
+	rootCmd.AddCommand(applyCmd)
+}
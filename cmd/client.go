@@ -0,0 +1,129 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"net/http"
+	"os"
+)
+
+// newClient returns an API client authenticated with the --api-key flag, the API_KEY environment
+// variable or the api_key of the profile, retrying requests as set with --timeout and --max-retries.
+// A page name given with --page-id is resolved to its identifier.
+func newClient() *client.Client {
+	apiKeyFromEnv := utils.GetEnv("API_KEY")
+	if apiKeyFromEnv == "" {
+		apiKeyFromEnv = profile.APIKey
+	}
+	apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
+	if apiKey == "" {
+		fmt.Fprintln(os.Stderr, "Set API_KEY as environment variable, specify --api-key flag or -k flag, or set api_key in a profile with 'statuspage config set api_key <API_KEY>'.")
+		os.Exit(exitAuth)
+	}
+
+	c := client.New(apiKey)
+	if profile.BaseURL != "" {
+		c.BaseURL = profile.BaseURL
+	}
+	c.HTTPClient = &http.Client{Transport: client.NewRetryTransport(maxRetries, timeout)}
+
+	var err error
+	pageID, err = lookup(c).pageID(pageID)
+	exitOnError(err)
+	return c
+}
+
+// Exit codes returned when a request fails, so scripts can tell failures apart.
+const (
+	exitError       = 1
+	exitAuth        = 3
+	exitNotFound    = 4
+	exitValidation  = 5
+	exitRateLimited = 6
+	exitServerError = 7
+)
+
+// exitOnError prints err on stderr and exits with the code matching its category when err is not nil.
+func exitOnError(err error) {
+	if err == nil {
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "Error:", err)
+	os.Exit(exitCode(err))
+}
+
+func exitCode(err error) int {
+	var apiErr *client.APIError
+	if !errors.As(err, &apiErr) {
+		return exitError
+	}
+
+	switch {
+	case apiErr.IsUnauthorized():
+		return exitAuth
+	case apiErr.IsNotFound():
+		return exitNotFound
+	case apiErr.IsValidation():
+		return exitValidation
+	case apiErr.IsRateLimited():
+		return exitRateLimited
+	case apiErr.IsServerError():
+		return exitServerError
+	}
+	return exitError
+}
+
+// changedString returns value when the flag was set on the command line and nil otherwise, so
+// unset flags are left out of the request.
+func changedString(cmd *cobra.Command, flag string, value string) *string {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+	return &value
+}
+
+// changedBool returns value when the flag was set on the command line and nil otherwise, so
+// unset flags are left out of the request.
+func changedBool(cmd *cobra.Command, flag string, value bool) *bool {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+	return &value
+}
+
+// changedInt returns value when the flag was set on the command line and nil otherwise, so
+// unset flags are left out of the request.
+func changedInt(cmd *cobra.Command, flag string, value int) *int {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+	return &value
+}
+
+// changedFloat returns value when the flag was set on the command line and nil otherwise, so
+// unset flags are left out of the request.
+func changedFloat(cmd *cobra.Command, flag string, value float64) *float64 {
+	if !cmd.Flags().Changed(flag) {
+		return nil
+	}
+	return &value
+}
@@ -16,14 +16,12 @@ limitations under the License.
 package cmd
 
 import (
+	"../client"
 	"../utils"
-	"bytes"
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
-	"io/ioutil"
-	"net/http"
 	"os"
-	"time"
 )
 
 var componentID string
@@ -31,42 +29,44 @@ var componentDescription string
 var componentStatus string
 var componentName string
 var componentShowcase bool
-var url string
+var componentGroup string
+var componentShowTree bool
+
+// componentTree is a list of components printed as a table grouped under their component groups.
+type componentTree []client.Component
 
 var getComponentCmd = &cobra.Command{
 	Use:   "component",
 	Short: "Get a list of components or a component with a specified component identifier.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
-
-		if componentID == "" {
-			url = "https://api.statuspage.io/v1/pages/" + pageID + "/components"
-		} else {
-			url = "https://api.statuspage.io/v1/pages/" + pageID + "/components/" + componentID
+		// The tree is only drawn as a table, other formats would silently lose the grouping.
+		if componentShowTree && cmd.Flags().Changed("output") && output != "table" {
+			exitOnError(errors.New("--tree is printed as a table and cannot be used with -o " + output))
 		}
 
-		request, err := http.NewRequest("GET", url, nil)
-		request.Header.Set("Authorization", "OAuth "+apiKey)
+		c := newClient()
 
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		if componentShowTree {
+			components, err := c.ListComponents(pageID, &client.ListOptions{All: true})
+			exitOnError(err)
+			exitOnError(writeTable(os.Stdout, componentTree(components)))
+			return
 		}
 
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		if componentID == "" {
+			printList(func(fn func([]client.Component) error) error {
+				return c.EachComponents(pageID, listOptions(), fn)
+			})
+			return
 		}
 
-		fmt.Println(string(body))
+		var err error
+		componentID, err = lookup(c).componentID(pageID, componentID)
+		exitOnError(err)
+
+		component, err := c.GetComponent(pageID, componentID)
+		exitOnError(err)
+		printOutput(component)
 	},
 }
 
@@ -74,39 +74,25 @@ var createComponentCmd = &cobra.Command{
 	Use:   "component",
 	Short: "Create a component.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
-
-		allowedComponentStatuses := []string{"operational", "under_maintenance", "degraded_performance", "partial_outage", "major_outage"}
-
-		if (utils.Contains(allowedComponentStatuses, componentStatus)) != true {
+		if (utils.Contains(client.ComponentStatuses, componentStatus)) != true {
 			cmd.Help()
 			os.Exit(1)
 		}
 
-		var jsonStr = []byte(fmt.Sprintf(`{"component": {"name": "%s", "description": "%s", "status": "%s", "showcase": %t}}`, componentName, componentDescription, componentStatus, componentShowcase))
-		request, err := http.NewRequest("POST", "https://api.statuspage.io/v1/pages/"+pageID+"/components", bytes.NewBuffer(jsonStr))
-		request.Header.Set("Authorization", "OAuth "+apiKey)
-		request.Header.Set("Content-Type", "application/json")
-
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
+		c := newClient()
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+		groupID, err := lookup(c).componentGroupID(pageID, componentGroup)
+		exitOnError(err)
 
-		fmt.Println(string(body))
+		component, err := c.CreateComponent(pageID, client.ComponentParams{
+			Name:        &componentName,
+			Description: &componentDescription,
+			Status:      &componentStatus,
+			Showcase:    changedBool(cmd, "showcase", componentShowcase),
+			GroupID:     changedString(cmd, "group", groupID),
+		})
+		exitOnError(err)
+		printOutput(component)
 	},
 }
 
@@ -114,40 +100,29 @@ var updateComponentCmd = &cobra.Command{
 	Use:   "component",
 	Short: "Update a component.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
-
-		allowedComponentStatuses := []string{"operational", "under_maintenance", "degraded_performance", "partial_outage", "major_outage"}
-
-		if (utils.Contains(allowedComponentStatuses, componentStatus)) != true {
+		if cmd.Flags().Changed("status") && (utils.Contains(client.ComponentStatuses, componentStatus)) != true {
 			cmd.Help()
 			os.Exit(1)
 		}
 
-		var jsonStr = []byte(fmt.Sprintf(`{"component": {"description": "%s", "status": "%s", "showcase": %t}}`, componentDescription, componentStatus, componentShowcase))
-		// fmt.Println(string(jsonStr))
-		request, err := http.NewRequest("PATCH", "https://api.statuspage.io/v1/pages/"+pageID+"/components/"+componentID, bytes.NewBuffer(jsonStr))
-		request.Header.Set("Authorization", "OAuth "+apiKey)
-		request.Header.Set("Content-Type", "application/json")
-
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		defer resp.Body.Close()
+		c := newClient()
 
-		body, err := ioutil.ReadAll(resp.Body)
+		var err error
+		componentID, err = lookup(c).componentID(pageID, componentID)
+		exitOnError(err)
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+		groupID, err := lookup(c).componentGroupID(pageID, componentGroup)
+		exitOnError(err)
 
-		fmt.Println(string(body))
+		component, err := c.UpdateComponent(pageID, componentID, client.ComponentParams{
+			Name:        changedString(cmd, "name", componentName),
+			Description: changedString(cmd, "description", componentDescription),
+			Status:      changedString(cmd, "status", componentStatus),
+			Showcase:    changedBool(cmd, "showcase", componentShowcase),
+			GroupID:     changedString(cmd, "group", groupID),
+		})
+		exitOnError(err)
+		printOutput(component)
 	},
 }
 
@@ -155,60 +130,48 @@ var deleteComponentCmd = &cobra.Command{
 	Use:   "component",
 	Short: "Delete a component with a specified component identifier.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
+		c := newClient()
 
-		request, err := http.NewRequest("DELETE", "https://api.statuspage.io/v1/pages/"+pageID+"/components/"+componentID, nil)
-		request.Header.Set("Authorization", "OAuth "+apiKey)
-
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+		var err error
+		componentID, err = lookup(c).componentID(pageID, componentID)
+		exitOnError(err)
 
-		fmt.Println(resp.StatusCode, string(body))
+		exitOnError(c.DeleteComponent(pageID, componentID))
+		fmt.Println("component " + componentID + " deleted")
 	},
 }
 
 func init() {
 	getComponentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	getComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
-	getComponentCmd.Flags().StringVarP(&componentID, "id", "i", "", "Component identifier")
+	getComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getComponentCmd.Flags().StringVarP(&componentID, "id", "i", "", "Component identifier or name")
+	getComponentCmd.Flags().BoolVar(&componentShowTree, "tree", false, "Show all components as a table, grouped under their component groups. Only -o table can be set with it")
 	getComponentCmd.MarkFlagRequired("page-id")
+	addListFlags(getComponentCmd)
 	createComponentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	createComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
+	createComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
 	createComponentCmd.Flags().StringVarP(&componentName, "name", "n", "", "Display name for component (required)")
 	createComponentCmd.Flags().StringVarP(&componentDescription, "description", "d", "", "More detailed description for component (required)")
 	createComponentCmd.Flags().StringVarP(&componentStatus, "status", "s", "", "Status of the component. Valid choices are: operational, under_maintenance, degraded_performance, partial_outage, major_outage (required)")
 	createComponentCmd.Flags().BoolVarP(&componentShowcase, "showcase", "c", false, "Should this component be showcased")
+	createComponentCmd.Flags().StringVarP(&componentGroup, "group", "g", "", "Identifier or name of the component group to add the component to")
 	createComponentCmd.MarkFlagRequired("page-id")
 	createComponentCmd.MarkFlagRequired("name")
 	createComponentCmd.MarkFlagRequired("description")
 	createComponentCmd.MarkFlagRequired("status")
 	updateComponentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	updateComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
-	updateComponentCmd.Flags().StringVarP(&componentID, "id", "i", "", "Component identifier (required)")
+	updateComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updateComponentCmd.Flags().StringVarP(&componentID, "id", "i", "", "Component identifier or name (required)")
+	updateComponentCmd.Flags().StringVarP(&componentName, "name", "n", "", "Display name for component")
 	updateComponentCmd.Flags().StringVarP(&componentDescription, "description", "d", "", "More detailed description for component")
-	updateComponentCmd.Flags().StringVarP(&componentStatus, "status", "s", "", "Status of the component. Valid choices are: operational, under_maintenance, degraded_performance, partial_outage, major_outage (required)")
+	updateComponentCmd.Flags().StringVarP(&componentStatus, "status", "s", "", "Status of the component. Valid choices are: operational, under_maintenance, degraded_performance, partial_outage, major_outage")
 	updateComponentCmd.Flags().BoolVarP(&componentShowcase, "showcase", "c", false, "Should this component be showcased")
+	updateComponentCmd.Flags().StringVarP(&componentGroup, "group", "g", "", "Identifier or name of the component group to move the component to")
 	updateComponentCmd.MarkFlagRequired("page-id")
 	updateComponentCmd.MarkFlagRequired("id")
-	updateComponentCmd.MarkFlagRequired("status")
 	deleteComponentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	deleteComponentCmd.Flags().StringVarP(&componentID, "id", "i", "", "Component identifier (required)")
-	deleteComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
+	deleteComponentCmd.Flags().StringVarP(&componentID, "id", "i", "", "Component identifier or name (required)")
+	deleteComponentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
 	deleteComponentCmd.MarkFlagRequired("id")
 	deleteComponentCmd.MarkFlagRequired("page-id")
 	createCmd.AddCommand(createComponentCmd)
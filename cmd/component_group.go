@@ -0,0 +1,138 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"github.com/spf13/cobra"
+)
+
+var componentGroupID string
+var componentGroupName string
+var componentGroupDescription string
+var componentGroupComponents []string
+
+var getComponentGroupCmd = &cobra.Command{
+	Use:   "component-group",
+	Short: "Get a list of component groups or a component group with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if componentGroupID == "" {
+			groups, err := c.ListComponentGroups(pageID, listOptions())
+			exitOnError(err)
+			printOutput(groups)
+			return
+		}
+
+		var err error
+		componentGroupID, err = lookup(c).componentGroupID(pageID, componentGroupID)
+		exitOnError(err)
+
+		group, err := c.GetComponentGroup(pageID, componentGroupID)
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+var createComponentGroupCmd = &cobra.Command{
+	Use:   "component-group",
+	Short: "Create a component group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		components, err := lookup(c).componentIDList(pageID, componentGroupComponents)
+		exitOnError(err)
+
+		group, err := c.CreateComponentGroup(pageID, changedString(cmd, "description", componentGroupDescription), client.ComponentGroupParams{
+			Name:       &componentGroupName,
+			Components: components,
+		})
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+var updateComponentGroupCmd = &cobra.Command{
+	Use:   "component-group",
+	Short: "Update a component group.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		componentGroupID, err = lookup(c).componentGroupID(pageID, componentGroupID)
+		exitOnError(err)
+
+		components, err := lookup(c).componentIDList(pageID, componentGroupComponents)
+		exitOnError(err)
+
+		group, err := c.UpdateComponentGroup(pageID, componentGroupID, changedString(cmd, "description", componentGroupDescription), client.ComponentGroupParams{
+			Name:       changedString(cmd, "name", componentGroupName),
+			Components: components,
+		})
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+var deleteComponentGroupCmd = &cobra.Command{
+	Use:   "component-group",
+	Short: "Delete a component group with a specified identifier. The components of the group are kept.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		componentGroupID, err = lookup(c).componentGroupID(pageID, componentGroupID)
+		exitOnError(err)
+
+		group, err := c.DeleteComponentGroup(pageID, componentGroupID)
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+func init() {
+	getComponentGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getComponentGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getComponentGroupCmd.Flags().StringVarP(&componentGroupID, "id", "i", "", "Component group identifier or name")
+	getComponentGroupCmd.MarkFlagRequired("page-id")
+	addListFlags(getComponentGroupCmd)
+	createComponentGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createComponentGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createComponentGroupCmd.Flags().StringVarP(&componentGroupName, "name", "n", "", "Display name for the component group (required)")
+	createComponentGroupCmd.Flags().StringVarP(&componentGroupDescription, "description", "d", "", "Description of the component group")
+	createComponentGroupCmd.Flags().StringSliceVarP(&componentGroupComponents, "components", "c", []string{}, "Identifiers or names of the components in the group (required)")
+	createComponentGroupCmd.MarkFlagRequired("page-id")
+	createComponentGroupCmd.MarkFlagRequired("name")
+	createComponentGroupCmd.MarkFlagRequired("components")
+	updateComponentGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updateComponentGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updateComponentGroupCmd.Flags().StringVarP(&componentGroupID, "id", "i", "", "Component group identifier or name (required)")
+	updateComponentGroupCmd.Flags().StringVarP(&componentGroupName, "name", "n", "", "Display name for the component group")
+	updateComponentGroupCmd.Flags().StringVarP(&componentGroupDescription, "description", "d", "", "Description of the component group")
+	updateComponentGroupCmd.Flags().StringSliceVarP(&componentGroupComponents, "components", "c", []string{}, "Identifiers or names of the components in the group, replacing the current components")
+	updateComponentGroupCmd.MarkFlagRequired("page-id")
+	updateComponentGroupCmd.MarkFlagRequired("id")
+	deleteComponentGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deleteComponentGroupCmd.Flags().StringVarP(&componentGroupID, "id", "i", "", "Component group identifier or name (required)")
+	deleteComponentGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	deleteComponentGroupCmd.MarkFlagRequired("id")
+	deleteComponentGroupCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getComponentGroupCmd)
+	createCmd.AddCommand(createComponentGroupCmd)
+	updateCmd.AddCommand(updateComponentGroupCmd)
+	deleteCmd.AddCommand(deleteComponentGroupCmd)
+}
@@ -0,0 +1,116 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../config"
+	"fmt"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manages the profiles in the configuration file",
+	// Profile settings are not applied to config commands, so they work with a profile that does not exist yet.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return nil
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("statuspage config error: missing required argument. See 'statuspage config -h' for help.")
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <setting> <value>",
+	Short: "Set a setting of the current profile, or of the profile selected with --profile.",
+	Long:  "Set a setting of the current profile, or of the profile selected with --profile. The profile is created when it does not exist.\nValid settings are: api_key, page_id, organization_id, base_url, output.",
+	Args:  cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := configProfileName()
+
+		profile, ok := cfg.Profiles[name]
+		if !ok {
+			profile = &config.Profile{}
+			cfg.Profiles[name] = profile
+		}
+		exitOnError(profile.Set(args[0], args[1]))
+
+		if cfg.CurrentProfile == "" {
+			cfg.CurrentProfile = name
+		}
+		exitOnError(cfg.Save(cfgFile))
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <setting>",
+	Short: "Print a setting of the current profile, or of the profile selected with --profile.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		profile, err := cfg.Profile(configProfileName())
+		exitOnError(err)
+
+		value, err := profile.Get(args[0])
+		exitOnError(err)
+		fmt.Println(value)
+	},
+}
+
+var configUseProfileCmd = &cobra.Command{
+	Use:   "use-profile <profile>",
+	Short: "Set the profile used when --profile and STATUSPAGE_PROFILE are not set.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		_, err := cfg.Profile(args[0])
+		exitOnError(err)
+
+		cfg.CurrentProfile = args[0]
+		exitOnError(cfg.Save(cfgFile))
+	},
+}
+
+var configListProfilesCmd = &cobra.Command{
+	Use:   "list-profiles",
+	Short: "List the profiles, marking the current profile with an asterisk.",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		current := profileName()
+		for _, name := range cfg.ProfileNames() {
+			if name == current {
+				fmt.Println("* " + name)
+			} else {
+				fmt.Println("  " + name)
+			}
+		}
+	},
+}
+
+// configProfileName returns the profile changed by config commands, falling back to the default
+// profile when none has been selected yet.
+func configProfileName() string {
+	if name := profileName(); name != "" {
+		return name
+	}
+	return config.DefaultProfile
+}
+
+func init() {
+	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configGetCmd)
+	configCmd.AddCommand(configUseProfileCmd)
+	configCmd.AddCommand(configListProfilesCmd)
+	rootCmd.AddCommand(configCmd)
+}
@@ -0,0 +1,73 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../manifest"
+	"../utils"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var diffPrune bool
+var diffExitCode bool
+var diffNoColor bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the differences between a manifest and the components, component groups and metrics of a page.",
+	Long: `Show the differences between a manifest and the components, component groups and metrics of a page,
+and its settings and incident templates when the manifest declares them, as the changes apply would make.
+See 'statuspage apply -h' for the format of manifests.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		m, err := manifest.Load(manifestFile)
+		exitOnError(err)
+
+		page, err := fetchManifestPage(c, m)
+		exitOnError(err)
+
+		changes, err := manifest.Plan(m, page, diffPrune)
+		exitOnError(err)
+
+		manifest.WriteDiff(os.Stdout, changes, colorOutput(diffNoColor))
+
+		// Like git diff --exit-code, differences exit with 1.
+		if diffExitCode && len(changes) > 0 {
+			os.Exit(exitError)
+		}
+	},
+}
+
+// colorOutput reports whether output should be coloured: stdout must be a terminal, and neither
+// --no-color nor the NO_COLOR environment variable set.
+func colorOutput(noColor bool) bool {
+	return !noColor && utils.GetEnv("NO_COLOR") == "" && isatty.IsTerminal(os.Stdout.Fd())
+}
+
+func init() {
+	diffCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	diffCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	diffCmd.Flags().StringVarP(&manifestFile, "filename", "f", "", "YAML or JSON manifest to compare, - to read it from stdin (required)")
+	diffCmd.Flags().BoolVar(&diffPrune, "prune", false, "Show the resources of the page that are not in the manifest as deleted, as apply --prune would")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with 1 when the page differs from the manifest, as git diff --exit-code does")
+	diffCmd.Flags().BoolVar(&diffNoColor, "no-color", false, "Do not colour the diff")
+	diffCmd.MarkFlagRequired("page-id")
+	diffCmd.MarkFlagRequired("filename")
+	rootCmd.AddCommand(diffCmd)
+}
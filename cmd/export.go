@@ -0,0 +1,70 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../manifest"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var exportFile string
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export the configuration of a page as a manifest.",
+	Long: `Export the settings, component groups, components, metrics and incident templates of a page, and
+its number of subscribers, as a YAML manifest. Identifiers, timestamps and positions are left out, so
+the manifest can be applied to the page it was exported from or to a new page.
+
+apply recreates every section but subscribers, which is a record of the page. Remove subdomain and
+domain from the page section before applying the manifest to a new page, as they are unique to a page.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		page, err := fetchManifestPage(c, nil)
+		exitOnError(err)
+
+		page.SubscriberCount, err = c.CountSubscribers(pageID)
+		exitOnError(err)
+
+		m, err := manifest.Export(page)
+		exitOnError(err)
+
+		if exportFile == "-" {
+			exitOnError(m.Write(os.Stdout))
+			return
+		}
+
+		f, err := os.Create(exportFile)
+		exitOnError(err)
+		err = m.Write(f)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		exitOnError(err)
+		fmt.Fprintf(os.Stderr, "page %s exported to %s\n", pageID, exportFile)
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	exportCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	exportCmd.Flags().StringVarP(&exportFile, "filename", "f", "-", "File to write the manifest to, - to write it to stdout")
+	exportCmd.MarkFlagRequired("page-id")
+	rootCmd.AddCommand(exportCmd)
+}
@@ -16,15 +16,13 @@ limitations under the License.
 package cmd
 
 import (
+	"../client"
 	"../utils"
-	"bytes"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/spf13/cobra"
-	"io/ioutil"
-	"net/http"
 	"os"
-	"time"
+	"strings"
 )
 
 var incidentID string
@@ -32,137 +30,158 @@ var incidentName string
 var incidentStatus string
 var incidentBody string
 var incidentComponents map[string]string
+var incidentTemplateRef string
+var incidentTemplateComponentStatus string
+var incidentVars map[string]string
+var incidentInteractive bool
 
 var getIncidentCmd = &cobra.Command{
 	Use:   "incident",
 	Short: "Get a list of incidents or a component with a specified incident identifier.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
+		c := newClient()
 
 		if incidentID == "" {
-			url = "https://api.statuspage.io/v1/pages/" + pageID + "/incidents"
-		} else {
-			url = "https://api.statuspage.io/v1/pages/" + pageID + "/incidents/" + incidentID
+			printList(func(fn func([]client.Incident) error) error {
+				return c.EachIncidents(pageID, listOptions(), fn)
+			})
+			return
 		}
 
-		request, err := http.NewRequest("GET", url, nil)
-		request.Header.Set("Authorization", "OAuth "+apiKey)
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
 
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		fmt.Println(string(body))
+		incident, err := c.GetIncident(pageID, incidentID)
+		exitOnError(err)
+		printOutput(incident)
 	},
 }
 
 var createIncidentCmd = &cobra.Command{
 	Use:   "incident",
 	Short: "Create an incident.",
+	Long: `Create an incident. With --template the name, status, body and affected components are taken
+from an incident template, unless they are set with flags. Go template variables in the name and
+body of the template, such as {{.region}}, are filled in with --var region=eu-west-1. The components
+of the template are set to the status given for them with --components, or else to
+--component-status, partial_outage by default.
+
+With --interactive, or when run on a terminal without a name or status, the name, status, affected
+components and body are asked for, suggesting the values set with flags or the template. The body
+is written in the editor set with VISUAL or EDITOR, and the incident is only created once the
+summary is confirmed.
+
+The body can also be read with --body-file, from stdin with --body-file -, or written in the editor
+with --edit. Its Markdown is rewritten as the plain text Statuspage shows, and bodies longer than
+Statuspage accepts are rejected before the incident is created.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
-
-		incidentComponentIDs := []string{}
-		for c, _ := range incidentComponents {
-			incidentComponentIDs = append(incidentComponentIDs, c)
-		}
-
-		jsonIncidentComponentIDs, _ := json.Marshal(incidentComponentIDs)
-		jsonIncidentComponents, _ := json.Marshal(incidentComponents)
-
-		allowedIncidentStatuses := []string{"investigating", "identified", "monitoring", "resolved", "scheduled", "in_progress", "verifying", "completed"}
-
-		if (utils.Contains(allowedIncidentStatuses, incidentStatus)) != true {
+		if (utils.Contains(client.ComponentStatuses, incidentTemplateComponentStatus)) != true {
 			cmd.Help()
 			os.Exit(1)
 		}
 
-		var jsonStr = []byte(fmt.Sprintf(`{"incident": {"name": "%s", "status": "%s", "body": "%s", "component_ids": %s, "components": %s}}`, incidentName, incidentStatus, incidentBody, jsonIncidentComponentIDs, jsonIncidentComponents))
-		request, err := http.NewRequest("POST", "https://api.statuspage.io/v1/pages/"+pageID+"/incidents", bytes.NewBuffer(jsonStr))
-		request.Header.Set("Authorization", "OAuth "+apiKey)
-		request.Header.Set("Content-Type", "application/json")
-
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
+		c := newClient()
+
+		body, err := incidentBodyFlags(cmd)
+		exitOnError(err)
+		templateComponents := map[string]string{}
+		if incidentTemplateRef != "" {
+			tmpl, err := lookup(c).incidentTemplate(pageID, incidentTemplateRef)
+			exitOnError(err)
+
+			if !cmd.Flags().Changed("name") {
+				incidentName, err = utils.RenderTemplate("title", tmpl.Title, incidentVars)
+				exitOnError(err)
+			}
+			if !cmd.Flags().Changed("status") {
+				incidentStatus = tmpl.UpdateStatus
+			}
+			if body == nil {
+				rendered, err := utils.RenderTemplate("body", tmpl.Body, incidentVars)
+				exitOnError(err)
+				body = &rendered
+			}
+			// Templates list the components they affect with their current status, not a status
+			// to set them to.
+			for _, component := range tmpl.Components {
+				templateComponents[component.ID] = incidentTemplateComponentStatus
+			}
+		}
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		components, err := lookup(c).componentIDs(pageID, incidentComponents)
+		exitOnError(err)
+		// Statuses set with --components take precedence over --component-status.
+		for id, status := range templateComponents {
+			if _, ok := components[id]; !ok {
+				components[id] = status
+			}
 		}
 
-		defer resp.Body.Close()
+		// On a terminal, a missing name or a missing or mistyped status is asked for instead of failing.
+		interactive := incidentInteractive || (terminal() && (incidentName == "" || !utils.Contains(client.IncidentStatuses, incidentStatus)))
+		if interactive {
+			body, components, err = promptIncident(c, body, components)
+			exitOnError(err)
+		}
 
-		body, err := ioutil.ReadAll(resp.Body)
+		if incidentName == "" {
+			exitOnError(errors.New("the incident name must be set with --name or --template"))
+		}
 
-		if err != nil {
-			fmt.Println(err)
+		if (utils.Contains(client.IncidentStatuses, incidentStatus)) != true {
+			cmd.Help()
 			os.Exit(1)
 		}
 
-		fmt.Println(string(body))
+		// The interactive questions already include editing the body.
+		if incidentEdit && !interactive {
+			body, err = editIncidentBody(body, fmt.Sprintf("Incident: %s\nStatus: %s", incidentName, incidentStatus))
+			exitOnError(err)
+		}
+
+		params := incidentParams(&incidentStatus, body, components)
+		params.Name = &incidentName
+		incident, err := createIncident(c, pageID, params)
+		exitOnError(err)
+		printOutput(incident)
 	},
 }
 
 var updateIncidentCmd = &cobra.Command{
 	Use:   "incident",
 	Short: "Update an incident.",
+	Long: `Update an incident. The message can be set with --body, read with --body-file, from stdin with
+--body-file -, or written in the editor with --edit, below the previous update of the incident. Its
+Markdown is rewritten as the plain text Statuspage shows, and messages longer than Statuspage
+accepts are rejected before the incident is updated.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
-
-		incidentComponentIDs := []string{}
-		for c, _ := range incidentComponents {
-			incidentComponentIDs = append(incidentComponentIDs, c)
-		}
+		c := newClient()
 
-		jsonIncidentComponentIDs, _ := json.Marshal(incidentComponentIDs)
-		jsonIncidentComponents, _ := json.Marshal(incidentComponents)
-
-		allowedIncidentStatuses := []string{"investigating", "identified", "monitoring", "resolved", "scheduled", "in_progress", "verifying", "completed"}
-
-		if (utils.Contains(allowedIncidentStatuses, incidentStatus)) != true {
+		if cmd.Flags().Changed("status") && (utils.Contains(client.IncidentStatuses, incidentStatus)) != true {
 			cmd.Help()
 			os.Exit(1)
 		}
 
-		var jsonStr = []byte(fmt.Sprintf(`{"incident": {"status": "%s", "body": "%s", "component_ids": %s, "components": %s}}`, incidentStatus, incidentBody, jsonIncidentComponentIDs, jsonIncidentComponents))
-		request, err := http.NewRequest("PATCH", "https://api.statuspage.io/v1/pages/"+pageID+"/incidents/"+incidentID, bytes.NewBuffer(jsonStr))
-		request.Header.Set("Authorization", "OAuth "+apiKey)
-		request.Header.Set("Content-Type", "application/json")
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
 
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
+		components, err := lookup(c).componentIDs(pageID, incidentComponents)
+		exitOnError(err)
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-
-		defer resp.Body.Close()
-
-		body, err := ioutil.ReadAll(resp.Body)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		body, err := incidentBodyFlags(cmd)
+		exitOnError(err)
+		if incidentEdit {
+			incident, err := c.GetIncident(pageID, incidentID)
+			exitOnError(err)
+			body, err = editIncidentBody(body, incidentContext(incident, incidentStatus))
+			exitOnError(err)
 		}
 
-		fmt.Println(string(body))
+		incident, err := updateIncident(c, pageID, incidentID, incidentParams(changedString(cmd, "status", incidentStatus), body, components))
+		exitOnError(err)
+		printOutput(incident)
 	},
 }
 
@@ -170,57 +189,114 @@ var deleteIncidentCmd = &cobra.Command{
 	Use:   "incident",
 	Short: "Delete an incident with a specified incident identifier.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
+		c := newClient()
 
-		request, err := http.NewRequest("DELETE", "https://api.statuspage.io/v1/pages/"+pageID+"/incidents/"+incidentID, nil)
-		request.Header.Set("Authorization", "OAuth "+apiKey)
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
 
-		client := &http.Client{Timeout: time.Second * 10}
-		resp, err := client.Do(request)
+		incident, err := c.DeleteIncident(pageID, incidentID)
+		exitOnError(err)
+		printOutput(incident)
+	},
+}
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+// incidentParams returns the fields of an incident created or updated with a status, a body and
+// the statuses of the components it affects, keyed by component identifier.
+func incidentParams(status, body *string, components map[string]string) client.IncidentParams {
+	return client.IncidentParams{
+		Status:       status,
+		Body:         body,
+		ComponentIDs: componentIDs(components),
+		Components:   components,
+	}
+}
 
-		defer resp.Body.Close()
+// createIncident checks the fields of a new incident and normalises its body before creating it,
+// for every command creating incidents and scheduled maintenances.
+func createIncident(c *client.Client, pageID string, params client.IncidentParams) (*client.Incident, error) {
+	if params.Name == nil || *params.Name == "" {
+		return nil, errors.New("the incident name is empty")
+	}
+	if params.Status == nil {
+		return nil, errors.New("the incident status is not set")
+	}
+	if err := checkIncidentParams(&params); err != nil {
+		return nil, err
+	}
+	return c.CreateIncident(pageID, params)
+}
 
-		body, err := ioutil.ReadAll(resp.Body)
+// updateIncident checks the fields of an incident update and normalises its body before posting
+// it, for every command updating incidents and scheduled maintenances or posting incident updates.
+func updateIncident(c *client.Client, pageID, incidentID string, params client.IncidentParams) (*client.Incident, error) {
+	if err := checkIncidentParams(&params); err != nil {
+		return nil, err
+	}
+	return c.UpdateIncident(pageID, incidentID, params)
+}
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+// checkIncidentParams checks the incident and component statuses of params, and rewrites its body
+// as the text Statuspage shows, rejecting bodies longer than Statuspage accepts.
+func checkIncidentParams(params *client.IncidentParams) error {
+	if params.Status != nil && !utils.Contains(client.IncidentStatuses, *params.Status) {
+		return fmt.Errorf("invalid incident status %q. Valid choices are: %s", *params.Status, strings.Join(client.IncidentStatuses, ", "))
+	}
+	for id, status := range params.Components {
+		if !utils.Contains(client.ComponentStatuses, status) {
+			return fmt.Errorf("invalid status %q for component %s. Valid choices are: %s", status, id, strings.Join(client.ComponentStatuses, ", "))
 		}
+	}
+
+	body, err := normalizeIncidentBody(params.Body)
+	if err != nil {
+		return err
+	}
+	params.Body = body
+	return nil
+}
 
-		fmt.Println(string(body))
-	},
+// componentIDs returns the identifiers of the components of a map of component statuses.
+func componentIDs(components map[string]string) []string {
+	ids := []string{}
+	for id := range components {
+		ids = append(ids, id)
+	}
+	return ids
 }
 
 func init() {
 	getIncidentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	getIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
-	getIncidentCmd.Flags().StringVarP(&incidentID, "id", "i", "", "Incident Identifier")
+	getIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getIncidentCmd.Flags().StringVarP(&incidentID, "id", "i", "", "Incident identifier or name")
 	getIncidentCmd.MarkFlagRequired("page-id")
+	addListFlags(getIncidentCmd)
 	createIncidentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	createIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
-	createIncidentCmd.Flags().StringVarP(&incidentName, "name", "n", "", "Incident name (required)")
+	createIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createIncidentCmd.Flags().StringVarP(&incidentName, "name", "n", "", "Incident name (required unless --template is set)")
 	createIncidentCmd.Flags().StringVarP(&incidentStatus, "status", "s", "", "The Incident status. Valid choices are: investigating, identified, monitoring, resolved, scheduled, in_progress, verifying, completed.")
 	createIncidentCmd.Flags().StringVarP(&incidentBody, "body", "b", "", "The initial message, created as the first incident update")
-	createIncidentCmd.Flags().StringToStringVarP(&incidentComponents, "components", "c", map[string]string{}, "Map of status changes to apply to affected components")
+	createIncidentCmd.Flags().StringVar(&incidentBodyFile, "body-file", "", "File to read the initial message from, - to read it from stdin")
+	createIncidentCmd.Flags().BoolVar(&incidentEdit, "edit", false, "Write the initial message in the editor set with VISUAL or EDITOR")
+	createIncidentCmd.Flags().StringToStringVarP(&incidentComponents, "components", "c", map[string]string{}, "Map of status changes to apply to affected components, keyed by component identifier or name")
+	createIncidentCmd.Flags().StringVar(&incidentTemplateRef, "template", "", "Identifier or name of the incident template to create the incident from")
+	createIncidentCmd.Flags().StringToStringVar(&incidentVars, "var", map[string]string{}, "Variables filled in the name and body of the template, as key=value")
+	createIncidentCmd.Flags().StringVar(&incidentTemplateComponentStatus, "component-status", "partial_outage", "Status of the components of the template not set with --components. Valid choices are: operational, under_maintenance, degraded_performance, partial_outage, major_outage")
+	createIncidentCmd.Flags().BoolVar(&incidentInteractive, "interactive", false, "Ask for the name, status, components and body of the incident, and for confirmation before creating it")
 	createIncidentCmd.MarkFlagRequired("page-id")
-	createIncidentCmd.MarkFlagRequired("name")
 	updateIncidentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	updateIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
-	updateIncidentCmd.Flags().StringVarP(&incidentID, "id", "i", "", "Incident identifier (required)")
+	updateIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updateIncidentCmd.Flags().StringVarP(&incidentID, "id", "i", "", "Incident identifier or name (required)")
 	updateIncidentCmd.Flags().StringVarP(&incidentStatus, "status", "s", "", "The incident status. Valid choices are: investigating, identified, monitoring, resolved, scheduled, in_progress, verifying, completed.")
-	updateIncidentCmd.Flags().StringVarP(&incidentBody, "body", "b", "", "The initial message, created as the first incident update")
-	updateIncidentCmd.Flags().StringToStringVarP(&incidentComponents, "components", "c", map[string]string{}, "Map of status changes to apply to affected components")
+	updateIncidentCmd.Flags().StringVarP(&incidentBody, "body", "b", "", "The message, created as a new incident update")
+	updateIncidentCmd.Flags().StringVar(&incidentBodyFile, "body-file", "", "File to read the message from, - to read it from stdin")
+	updateIncidentCmd.Flags().BoolVar(&incidentEdit, "edit", false, "Write the message in the editor set with VISUAL or EDITOR, below the previous update of the incident")
+	updateIncidentCmd.Flags().StringToStringVarP(&incidentComponents, "components", "c", map[string]string{}, "Map of status changes to apply to affected components, keyed by component identifier or name")
 	updateIncidentCmd.MarkFlagRequired("page-id")
 	updateIncidentCmd.MarkFlagRequired("id")
 	deleteIncidentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
-	deleteIncidentCmd.Flags().StringVarP(&incidentID, "id", "i", "", "Incident Identifier (required)")
-	deleteIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier (required)")
+	deleteIncidentCmd.Flags().StringVarP(&incidentID, "id", "i", "", "Incident identifier or name (required)")
+	deleteIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
 	deleteIncidentCmd.MarkFlagRequired("id")
 	deleteIncidentCmd.MarkFlagRequired("page-id")
 	getCmd.AddCommand(getIncidentCmd)
@@ -0,0 +1,111 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"strings"
+	"unicode/utf8"
+)
+
+var incidentBodyFile string
+var incidentEdit bool
+
+// maxIncidentBodyLength is the longest incident body, in characters, that Statuspage accepts.
+const maxIncidentBodyLength = 25000
+
+// incidentBodyFlags returns the body set with --body or read from the file given with --body-file,
+// or nil when neither is set.
+func incidentBodyFlags(cmd *cobra.Command) (*string, error) {
+	if !cmd.Flags().Changed("body-file") {
+		return changedString(cmd, "body", incidentBody), nil
+	}
+	if cmd.Flags().Changed("body") {
+		return nil, errors.New("only one of --body and --body-file can be set")
+	}
+	// The editor and the prompts need stdin to be the terminal.
+	if incidentBodyFile == "-" && (incidentEdit || incidentInteractive) {
+		return nil, errors.New("--body-file - reads the body from stdin, it cannot be used with --edit or --interactive")
+	}
+
+	data, err := utils.ReadFile(incidentBodyFile)
+	if err != nil {
+		return nil, err
+	}
+	body := string(data)
+	return &body, nil
+}
+
+// editIncidentBody opens body in the editor with context shown below it, commented out. The
+// incident is not posted when the edited body is empty.
+func editIncidentBody(body *string, context string) (*string, error) {
+	text := ""
+	if body != nil {
+		text = *body
+	}
+
+	lines := []string{}
+	for _, line := range strings.Split(context, "\n") {
+		lines = append(lines, strings.TrimRight("# "+line, " "))
+	}
+
+	text, err := utils.EditText(text, strings.Join(lines, "\n"))
+	if err != nil {
+		return nil, fmt.Errorf("error editing the incident body: %v", err)
+	}
+	if text == "" {
+		return nil, errors.New("the incident body is empty, nothing was posted")
+	}
+	return &text, nil
+}
+
+// incidentContext describes an incident and its latest update, shown when editing the body of
+// its next update.
+func incidentContext(incident *client.Incident, status string) string {
+	if status == "" {
+		status = incident.Status
+	}
+	context := fmt.Sprintf("Incident: %s\nStatus: %s", incident.Name, status)
+
+	var latest *client.IncidentUpdate
+	for i, update := range incident.IncidentUpdates {
+		if latest == nil || update.CreatedAt.After(latest.CreatedAt) {
+			latest = &incident.IncidentUpdates[i]
+		}
+	}
+	if latest != nil {
+		context += fmt.Sprintf("\n\nPrevious update, %s at %s:\n\n%s", latest.Status, formatTime(latest.CreatedAt), latest.Body)
+	}
+	return context
+}
+
+// normalizeIncidentBody rewrites the Markdown of body as the text shown by Statuspage and checks
+// its length before it is sent.
+func normalizeIncidentBody(body *string) (*string, error) {
+	if body == nil {
+		return nil, nil
+	}
+
+	text := utils.NormalizeMarkdown(*body)
+	if n := utf8.RuneCountInString(text); n > maxIncidentBodyLength {
+		return nil, fmt.Errorf("the incident body is %d characters long, Statuspage accepts up to %d", n, maxIncidentBodyLength)
+	}
+	return &text, nil
+}
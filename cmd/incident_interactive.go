@@ -0,0 +1,119 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// promptIncident asks for the name, status, affected components and body of a new incident,
+// suggesting the values set with flags or the template, shows a summary and asks to confirm it.
+// The name and status are set in incidentName and incidentStatus, and the body and component
+// statuses, keyed by component identifier, are returned.
+func promptIncident(c *client.Client, body *string, components map[string]string) (*string, map[string]string, error) {
+	if !terminal() {
+		return nil, nil, errors.New("--interactive needs a terminal to ask for the incident")
+	}
+
+	var err error
+	if incidentName, err = promptString("Name", incidentName); err != nil {
+		return nil, nil, err
+	}
+
+	if !utils.Contains(client.IncidentStatuses, incidentStatus) {
+		if incidentStatus != "" {
+			fmt.Fprintf(os.Stderr, "%q is not an incident status\n", incidentStatus)
+		}
+		incidentStatus = client.IncidentStatuses[0]
+	}
+	if incidentStatus, err = promptSelect("Status", client.IncidentStatuses, incidentStatus); err != nil {
+		return nil, nil, err
+	}
+
+	list, err := c.ListComponents(pageID, &client.ListOptions{All: true})
+	if err != nil {
+		return nil, nil, err
+	}
+	// Groups take the worst status of their components and cannot be set themselves.
+	candidates := []client.Component{}
+	choices := []string{}
+	selected := []int{}
+	for _, component := range list {
+		if component.Group {
+			continue
+		}
+		if _, ok := components[component.ID]; ok {
+			selected = append(selected, len(candidates))
+		}
+		candidates = append(candidates, component)
+		choices = append(choices, fmt.Sprintf("%s (%s)", component.Name, component.Status))
+	}
+
+	chosen, err := promptMultiSelect("Affected components, by number separated by commas, or none", choices, selected)
+	if err != nil {
+		return nil, nil, err
+	}
+	statuses := map[string]string{}
+	names := []string{}
+	for _, i := range chosen {
+		component := candidates[i]
+		status, ok := components[component.ID]
+		if !ok {
+			status = "partial_outage"
+		}
+		if status, err = promptSelect("Status of "+component.Name, client.ComponentStatuses, status); err != nil {
+			return nil, nil, err
+		}
+		statuses[component.ID] = status
+		names = append(names, component.Name+": "+status)
+	}
+
+	text := ""
+	if body != nil {
+		text = *body
+	}
+	text, err = utils.EditText(text, fmt.Sprintf("# Write the message of the incident %q above, it is posted as its first update.", incidentName))
+	if err != nil {
+		return nil, nil, fmt.Errorf("error editing the incident body: %v", err)
+	}
+	body = nil
+	if text != "" {
+		body = &text
+	}
+
+	fmt.Fprintf(os.Stderr, "\nName:       %s\nStatus:     %s\n", incidentName, incidentStatus)
+	if len(names) > 0 {
+		fmt.Fprintf(os.Stderr, "Components: %s\n", strings.Join(names, "\n            "))
+	}
+	if body != nil {
+		fmt.Fprintf(os.Stderr, "Body:\n  %s\n", strings.Replace(*body, "\n", "\n  ", -1))
+	}
+	fmt.Fprintln(os.Stderr)
+
+	ok, err := promptConfirm("Create this incident?")
+	if err != nil {
+		return nil, nil, err
+	}
+	if !ok {
+		return nil, nil, errors.New("the incident was not created")
+	}
+	return body, statuses, nil
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var incidentTemplateID string
+var incidentTemplateName string
+var incidentTemplateTitle string
+var incidentTemplateBody string
+var incidentTemplateStatus string
+var incidentTemplateComponents []string
+var incidentTemplateShouldTweet bool
+var incidentTemplateShouldSendNotifications bool
+
+var getIncidentTemplateCmd = &cobra.Command{
+	Use:   "incident-template",
+	Short: "Get a list of incident templates or an incident template with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if incidentTemplateID == "" {
+			templates, err := c.ListIncidentTemplates(pageID, listOptions())
+			exitOnError(err)
+			printOutput(templates)
+			return
+		}
+
+		tmpl, err := lookup(c).incidentTemplate(pageID, incidentTemplateID)
+		exitOnError(err)
+		printOutput(tmpl)
+	},
+}
+
+var createIncidentTemplateCmd = &cobra.Command{
+	Use:   "incident-template",
+	Short: "Create an incident template.",
+	Long: `Create an incident template. The title and body can hold Go template variables such as
+{{.region}}, which are filled in with --var when an incident is created from the template.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if cmd.Flags().Changed("status") && (utils.Contains(client.IncidentStatuses, incidentTemplateStatus)) != true {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		components, err := lookup(c).componentIDList(pageID, incidentTemplateComponents)
+		exitOnError(err)
+
+		tmpl, err := c.CreateIncidentTemplate(pageID, client.IncidentTemplateParams{
+			Name:                    &incidentTemplateName,
+			Title:                   &incidentTemplateTitle,
+			Body:                    &incidentTemplateBody,
+			UpdateStatus:            changedString(cmd, "status", incidentTemplateStatus),
+			ShouldTweet:             changedBool(cmd, "should-tweet", incidentTemplateShouldTweet),
+			ShouldSendNotifications: changedBool(cmd, "should-send-notifications", incidentTemplateShouldSendNotifications),
+			ComponentIDs:            components,
+		})
+		exitOnError(err)
+		printOutput(tmpl)
+	},
+}
+
+var deleteIncidentTemplateCmd = &cobra.Command{
+	Use:   "incident-template",
+	Short: "Delete an incident template with a specified identifier (experimental).",
+	Long: `Delete an incident template with a specified identifier. This command is experimental: the
+public API only documents listing and creating incident templates, so deleting them may be rejected,
+in which case the template has to be deleted in the web UI.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		tmpl, err := lookup(c).incidentTemplate(pageID, incidentTemplateID)
+		exitOnError(err)
+
+		err = c.DeleteIncidentTemplate(pageID, tmpl.ID)
+		var apiErr *client.APIError
+		if errors.As(err, &apiErr) && (apiErr.IsNotFound() || apiErr.StatusCode == 405) {
+			// The template was just listed, so a missing endpoint rather than template is to blame.
+			exitOnError(fmt.Errorf("the API does not allow deleting incident templates of this page, delete %q in the web UI: %v", tmpl.Name, err))
+		}
+		exitOnError(err)
+		fmt.Println("incident template " + tmpl.ID + " deleted")
+	},
+}
+
+func init() {
+	getIncidentTemplateCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getIncidentTemplateCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getIncidentTemplateCmd.Flags().StringVarP(&incidentTemplateID, "id", "i", "", "Incident template identifier or name")
+	getIncidentTemplateCmd.MarkFlagRequired("page-id")
+	addListFlags(getIncidentTemplateCmd)
+	createIncidentTemplateCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createIncidentTemplateCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createIncidentTemplateCmd.Flags().StringVarP(&incidentTemplateName, "name", "n", "", "Name of the template (required)")
+	createIncidentTemplateCmd.Flags().StringVarP(&incidentTemplateTitle, "title", "t", "", "Name given to incidents created from the template (required)")
+	createIncidentTemplateCmd.Flags().StringVarP(&incidentTemplateBody, "body", "b", "", "Message of incidents created from the template (required)")
+	createIncidentTemplateCmd.Flags().StringVarP(&incidentTemplateStatus, "status", "s", "", "Status of incidents created from the template. Valid choices are: investigating, identified, monitoring, resolved, scheduled, in_progress, verifying, completed.")
+	createIncidentTemplateCmd.Flags().StringSliceVarP(&incidentTemplateComponents, "components", "c", []string{}, "Identifiers or names of the components affected by incidents created from the template")
+	createIncidentTemplateCmd.Flags().BoolVar(&incidentTemplateShouldTweet, "should-tweet", false, "Tweet incidents created from the template")
+	createIncidentTemplateCmd.Flags().BoolVar(&incidentTemplateShouldSendNotifications, "should-send-notifications", false, "Notify subscribers of incidents created from the template")
+	createIncidentTemplateCmd.MarkFlagRequired("page-id")
+	createIncidentTemplateCmd.MarkFlagRequired("name")
+	createIncidentTemplateCmd.MarkFlagRequired("title")
+	createIncidentTemplateCmd.MarkFlagRequired("body")
+	deleteIncidentTemplateCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deleteIncidentTemplateCmd.Flags().StringVarP(&incidentTemplateID, "id", "i", "", "Incident template identifier or name (required)")
+	deleteIncidentTemplateCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	deleteIncidentTemplateCmd.MarkFlagRequired("id")
+	deleteIncidentTemplateCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getIncidentTemplateCmd)
+	createCmd.AddCommand(createIncidentTemplateCmd)
+	deleteCmd.AddCommand(deleteIncidentTemplateCmd)
+}
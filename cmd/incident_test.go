@@ -0,0 +1,60 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckIncidentParams(t *testing.T) {
+	str := func(s string) *string { return &s }
+
+	for _, tc := range []struct {
+		name       string
+		status     *string
+		body       *string
+		components map[string]string
+		wantBody   *string
+		wantErr    string
+	}{
+		{name: "no fields"},
+		{name: "status and body", status: str("identified"), body: str("# Fixing\r\n"), wantBody: str("Fixing")},
+		{name: "invalid status", status: str("fixed"), wantErr: `invalid incident status "fixed"`},
+		{name: "invalid component status", components: map[string]string{"c1": "down"}, wantErr: `invalid status "down" for component c1`},
+		{name: "long body", body: str(strings.Repeat("a", maxIncidentBodyLength+1)), wantErr: "Statuspage accepts up to"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			params := incidentParams(tc.status, tc.body, tc.components)
+			err := checkIncidentParams(&params)
+			if tc.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tc.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if (params.Body == nil) != (tc.wantBody == nil) {
+				t.Fatalf("body = %v, want %v", params.Body, tc.wantBody)
+			}
+			if params.Body != nil && *params.Body != *tc.wantBody {
+				t.Errorf("body = %q, want %q", *params.Body, *tc.wantBody)
+			}
+		})
+	}
+}
@@ -0,0 +1,135 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+var incidentUpdateID string
+var incidentUpdateBody string
+var incidentUpdateStatus string
+var incidentUpdateDisplayAt string
+var incidentUpdateWantsTwitterUpdate bool
+var incidentUpdateDeliverNotifications bool
+
+var getIncidentUpdatesCmd = &cobra.Command{
+	Use:     "incident-updates",
+	Aliases: []string{"incident-update"},
+	Short:   "Get the timeline of updates of an incident, most recent first.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		incidentUpdates, err := c.ListIncidentUpdates(pageID, incidentID)
+		exitOnError(err)
+		printOutput(incidentUpdates)
+	},
+}
+
+var createIncidentUpdateCmd = &cobra.Command{
+	Use:   "incident-update",
+	Short: "Post an update to the timeline of an incident.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if cmd.Flags().Changed("status") && (utils.Contains(client.IncidentStatuses, incidentUpdateStatus)) != true {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		incident, err := updateIncident(c, pageID, incidentID, client.IncidentParams{
+			Status:               changedString(cmd, "status", incidentUpdateStatus),
+			Body:                 &incidentUpdateBody,
+			DeliverNotifications: changedBool(cmd, "deliver-notifications", incidentUpdateDeliverNotifications),
+		})
+		exitOnError(err)
+		if len(incident.IncidentUpdates) == 0 {
+			exitOnError(errors.New("the incident was updated but the API did not return the new update"))
+		}
+		printOutput(&incident.IncidentUpdates[0])
+	},
+}
+
+var updateIncidentUpdateCmd = &cobra.Command{
+	Use:   "incident-update",
+	Short: "Edit an update in the timeline of an incident.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		params := client.IncidentUpdateParams{
+			Body:                 changedString(cmd, "body", incidentUpdateBody),
+			WantsTwitterUpdate:   changedBool(cmd, "wants-twitter-update", incidentUpdateWantsTwitterUpdate),
+			DeliverNotifications: changedBool(cmd, "deliver-notifications", incidentUpdateDeliverNotifications),
+		}
+		if cmd.Flags().Changed("display-at") {
+			displayAt, err := utils.ParseTime(incidentUpdateDisplayAt, time.Now())
+			exitOnError(err)
+			params.DisplayAt = &displayAt
+		}
+
+		incidentUpdate, err := c.UpdateIncidentUpdate(pageID, incidentID, incidentUpdateID, params)
+		exitOnError(err)
+		printOutput(incidentUpdate)
+	},
+}
+
+func init() {
+	getIncidentUpdatesCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getIncidentUpdatesCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getIncidentUpdatesCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	getIncidentUpdatesCmd.MarkFlagRequired("page-id")
+	getIncidentUpdatesCmd.MarkFlagRequired("incident")
+	createIncidentUpdateCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createIncidentUpdateCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createIncidentUpdateCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	createIncidentUpdateCmd.Flags().StringVarP(&incidentUpdateBody, "body", "b", "", "The message of the update (required)")
+	createIncidentUpdateCmd.Flags().StringVarP(&incidentUpdateStatus, "status", "s", "", "The incident status. Valid choices are: investigating, identified, monitoring, resolved, scheduled, in_progress, verifying, completed.")
+	createIncidentUpdateCmd.Flags().BoolVar(&incidentUpdateDeliverNotifications, "deliver-notifications", true, "Notify subscribers of the update")
+	createIncidentUpdateCmd.MarkFlagRequired("page-id")
+	createIncidentUpdateCmd.MarkFlagRequired("incident")
+	createIncidentUpdateCmd.MarkFlagRequired("body")
+	updateIncidentUpdateCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updateIncidentUpdateCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updateIncidentUpdateCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	updateIncidentUpdateCmd.Flags().StringVarP(&incidentUpdateID, "id", "i", "", "Incident update identifier (required)")
+	updateIncidentUpdateCmd.Flags().StringVarP(&incidentUpdateBody, "body", "b", "", "The corrected message of the update")
+	updateIncidentUpdateCmd.Flags().StringVar(&incidentUpdateDisplayAt, "display-at", "", "Time the update is shown as posted at, e.g. \"2020-06-01 02:00\" or RFC 3339")
+	updateIncidentUpdateCmd.Flags().BoolVar(&incidentUpdateWantsTwitterUpdate, "wants-twitter-update", false, "Post the update to Twitter")
+	updateIncidentUpdateCmd.Flags().BoolVar(&incidentUpdateDeliverNotifications, "deliver-notifications", false, "Notify subscribers of the update")
+	updateIncidentUpdateCmd.MarkFlagRequired("page-id")
+	updateIncidentUpdateCmd.MarkFlagRequired("incident")
+	updateIncidentUpdateCmd.MarkFlagRequired("id")
+	getCmd.AddCommand(getIncidentUpdatesCmd)
+	createCmd.AddCommand(createIncidentUpdateCmd)
+	updateCmd.AddCommand(updateIncidentUpdateCmd)
+}
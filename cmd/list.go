@@ -0,0 +1,118 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"encoding/json"
+	"fmt"
+	"github.com/spf13/cobra"
+	"io"
+	"os"
+	"strings"
+)
+
+var listPage int
+var listPerPage int
+var listAll bool
+var listLimit int
+
+// addListFlags adds the pagination flags shared by the commands listing resources.
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&listPage, "page", 0, "Page of results to fetch, starting at 1")
+	cmd.Flags().IntVar(&listPerPage, "per-page", 0, "Number of results to fetch per page")
+	cmd.Flags().BoolVar(&listAll, "all", false, "Fetch all pages of results")
+	cmd.Flags().IntVar(&listLimit, "limit", 0, "Maximum number of results to fetch, following pages until it is reached")
+}
+
+// listOptions returns the list options set with the pagination flags.
+func listOptions() *client.ListOptions {
+	return &client.ListOptions{
+		Page:    listPage,
+		PerPage: listPerPage,
+		All:     listAll,
+		Limit:   listLimit,
+	}
+}
+
+// streamOutput reports whether results can be printed page by page as they are fetched,
+// rather than once all pages are in. Tables align their columns over the whole list, and
+// jsonpath and go-template expressions are evaluated against it, so they wait for every page.
+func streamOutput() bool {
+	return output != "table" && !strings.HasPrefix(output, "jsonpath=") && !strings.HasPrefix(output, "go-template=")
+}
+
+// printList prints the results passed by each to its callback, page by page as they are fetched
+// when the output format allows it. The output is the same as printing the whole list at once.
+// S is the type the whole list is printed as, such as maintenances for scheduled maintenances.
+func printList[S ~[]T, T any](each func(fn func(S) error) error) {
+	exitOnError(writeList(os.Stdout, each))
+}
+
+func writeList[S ~[]T, T any](w io.Writer, each func(fn func(S) error) error) error {
+	if !streamOutput() {
+		results := S{}
+		err := each(func(page S) error {
+			results = append(results, page...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		return writeOutput(w, results)
+	}
+
+	count := 0
+	err := each(func(page S) error {
+		defer func() { count += len(page) }()
+
+		switch output {
+		case "json":
+			for i, result := range page {
+				out, err := json.MarshalIndent(result, "  ", "  ")
+				if err != nil {
+					return err
+				}
+				separator := ",\n  "
+				if count+i == 0 {
+					separator = "[\n  "
+				}
+				fmt.Fprint(w, separator+string(out))
+			}
+			return nil
+		case "yaml":
+			if len(page) == 0 {
+				return nil
+			}
+			return writeOutput(w, page)
+		}
+		return writeOutput(w, page)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Close the JSON array, or print the empty list when there were no results.
+	switch {
+	case output == "json" && count > 0:
+		fmt.Fprintln(w, "\n]")
+	case output == "json":
+		fmt.Fprintln(w, "[]")
+	case output == "yaml" && count == 0:
+		return writeOutput(w, S{})
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"bytes"
+	"testing"
+)
+
+func TestWriteList(t *testing.T) {
+	components := []client.Component{{ID: "c1", Name: "API"}, {ID: "c2", Name: "Web"}, {ID: "c3", Name: "Database"}}
+
+	tests := []struct {
+		name  string
+		pages [][]client.Component
+	}{
+		{"no pages", nil},
+		{"empty page", [][]client.Component{{}}},
+		{"one page", [][]client.Component{components}},
+		{"several pages", [][]client.Component{components[:2], components[2:]}},
+		{"empty last page", [][]client.Component{components[:2], components[2:], {}}},
+	}
+
+	defer func(format string) { output = format }(output)
+	for _, format := range []string{"json", "yaml", "table", "name", "jsonpath={.[*].id}"} {
+		output = format
+		for _, test := range tests {
+			all := []client.Component{}
+			for _, page := range test.pages {
+				all = append(all, page...)
+			}
+			var want bytes.Buffer
+			if err := writeOutput(&want, all); err != nil {
+				t.Fatal(err)
+			}
+
+			var got bytes.Buffer
+			err := writeList(&got, func(fn func([]client.Component) error) error {
+				for _, page := range test.pages {
+					if err := fn(page); err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.String() != want.String() {
+				t.Errorf("-o %s, %s: got\n%s\nwant\n%s", format, test.name, got.String(), want.String())
+			}
+		}
+	}
+}
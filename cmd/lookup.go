@@ -0,0 +1,463 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// idPattern matches Statuspage identifiers. References matching it are fetched by identifier first,
+// and looked up by name when there is no such resource, as names like "loadbalancer" match it too.
+var idPattern = regexp.MustCompile(`^[a-z0-9]{12}$`)
+
+// named is a resource that can be referred to by identifier or name.
+type named struct {
+	id   string
+	name string
+}
+
+// lookupCache turns references given on the command line, either identifiers or names, into
+// identifiers. The lists fetched to look names up are cached for the rest of the invocation.
+type lookupCache struct {
+	client       *client.Client
+	pages        []named
+	components   map[string][]named
+	groups       map[string][]named
+	incidents    map[string][]named
+	templates    map[string][]client.IncidentTemplate
+	metrics      map[string][]named
+	providers    map[string][]named
+	users        map[string][]named
+	accessGroups map[string][]named
+	orgUsers     map[string][]client.User
+	found        map[string]bool
+}
+
+var currentLookup *lookupCache
+
+// lookup returns the lookup cache of the invocation, using c to fetch the lists it needs.
+func lookup(c *client.Client) *lookupCache {
+	if currentLookup == nil {
+		currentLookup = &lookupCache{
+			client:       c,
+			components:   map[string][]named{},
+			groups:       map[string][]named{},
+			incidents:    map[string][]named{},
+			templates:    map[string][]client.IncidentTemplate{},
+			metrics:      map[string][]named{},
+			providers:    map[string][]named{},
+			users:        map[string][]named{},
+			accessGroups: map[string][]named{},
+			orgUsers:     map[string][]client.User{},
+			found:        map[string]bool{},
+		}
+	}
+	return currentLookup
+}
+
+// pageID returns the identifier of the page identified or named by ref.
+func (l *lookupCache) pageID(ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	if l.pages == nil {
+		if found, err := l.exists("page/"+ref, ref, func() error {
+			_, err := l.client.GetPage(ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		pages, err := l.client.ListPages()
+		if err != nil {
+			return "", err
+		}
+		l.pages = []named{}
+		for _, p := range pages {
+			l.pages = append(l.pages, named{p.ID, p.Name})
+		}
+	}
+	return match("page", ref, l.pages)
+}
+
+// componentID returns the identifier of the component of a page identified or named by ref.
+func (l *lookupCache) componentID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	if _, ok := l.components[pageID]; !ok {
+		if found, err := l.exists("component/"+pageID+"/"+ref, ref, func() error {
+			_, err := l.client.GetComponent(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		components, err := l.client.ListComponents(pageID, &client.ListOptions{All: true})
+		if err != nil {
+			return "", err
+		}
+		l.components[pageID] = []named{}
+		for _, c := range components {
+			l.components[pageID] = append(l.components[pageID], named{c.ID, c.Name})
+		}
+	}
+	return match("component", ref, l.components[pageID])
+}
+
+// componentIDs returns the component statuses passed with --components keyed by component identifier.
+func (l *lookupCache) componentIDs(pageID string, statuses map[string]string) (map[string]string, error) {
+	resolved := map[string]string{}
+	for ref, status := range statuses {
+		id, err := l.componentID(pageID, ref)
+		if err != nil {
+			return nil, err
+		}
+		resolved[id] = status
+	}
+	return resolved, nil
+}
+
+// componentIDList returns the identifiers of a list of components identified or named by refs.
+func (l *lookupCache) componentIDList(pageID string, refs []string) ([]string, error) {
+	ids := []string{}
+	for _, ref := range refs {
+		id, err := l.componentID(pageID, ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// componentGroupID returns the identifier of the component group of a page identified or named by ref.
+func (l *lookupCache) componentGroupID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	if _, ok := l.groups[pageID]; !ok {
+		if found, err := l.exists("component group/"+pageID+"/"+ref, ref, func() error {
+			_, err := l.client.GetComponentGroup(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		groups, err := l.client.ListComponentGroups(pageID, &client.ListOptions{All: true})
+		if err != nil {
+			return "", err
+		}
+		l.groups[pageID] = []named{}
+		for _, g := range groups {
+			l.groups[pageID] = append(l.groups[pageID], named{g.ID, g.Name})
+		}
+	}
+	return match("component group", ref, l.groups[pageID])
+}
+
+// incidentID returns the identifier of the incident of a page identified or named by ref.
+// Incidents are searched by name rather than listed, as a page may have thousands of them.
+func (l *lookupCache) incidentID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	key := pageID + "/" + ref
+	if _, ok := l.incidents[key]; !ok {
+		if found, err := l.exists("incident/"+key, ref, func() error {
+			_, err := l.client.GetIncident(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		incidents, err := l.client.ListIncidents(pageID, &client.ListOptions{All: true, Query: url.Values{"q": {ref}}})
+		if err != nil {
+			return "", err
+		}
+		l.incidents[key] = []named{}
+		for _, i := range incidents {
+			l.incidents[key] = append(l.incidents[key], named{i.ID, i.Name})
+		}
+	}
+	return match("incident", ref, l.incidents[key])
+}
+
+// incidentTemplate returns the incident template of a page identified or named by ref. Templates
+// cannot be fetched one at a time, so they are always listed.
+func (l *lookupCache) incidentTemplate(pageID, ref string) (*client.IncidentTemplate, error) {
+	if _, ok := l.templates[pageID]; !ok {
+		templates, err := l.client.ListIncidentTemplates(pageID, &client.ListOptions{All: true})
+		if err != nil {
+			return nil, err
+		}
+		l.templates[pageID] = templates
+	}
+
+	candidates := []named{}
+	for _, t := range l.templates[pageID] {
+		candidates = append(candidates, named{t.ID, t.Name})
+	}
+	id, err := match("incident template", ref, candidates)
+	if err != nil {
+		return nil, err
+	}
+	for i, t := range l.templates[pageID] {
+		if t.ID == id {
+			return &l.templates[pageID][i], nil
+		}
+	}
+	return nil, fmt.Errorf("no incident template with the identifier or name %q", ref)
+}
+
+// metricID returns the identifier of the metric of a page identified or named by ref.
+func (l *lookupCache) metricID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	if _, ok := l.metrics[pageID]; !ok {
+		if found, err := l.exists("metric/"+pageID+"/"+ref, ref, func() error {
+			_, err := l.client.GetMetric(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		metrics, err := l.client.ListMetrics(pageID, &client.ListOptions{All: true})
+		if err != nil {
+			return "", err
+		}
+		l.metrics[pageID] = []named{}
+		for _, m := range metrics {
+			l.metrics[pageID] = append(l.metrics[pageID], named{m.ID, m.Name})
+		}
+	}
+	return match("metric", ref, l.metrics[pageID])
+}
+
+// metricsProviderID returns the identifier of the metrics provider of a page identified by ref or
+// of the type ref, such as Self or Pingdom.
+func (l *lookupCache) metricsProviderID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	if _, ok := l.providers[pageID]; !ok {
+		if found, err := l.exists("metrics provider/"+pageID+"/"+ref, ref, func() error {
+			_, err := l.client.GetMetricsProvider(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		providers, err := l.client.ListMetricsProviders(pageID, &client.ListOptions{All: true})
+		if err != nil {
+			return "", err
+		}
+		l.providers[pageID] = []named{}
+		for _, p := range providers {
+			l.providers[pageID] = append(l.providers[pageID], named{p.ID, p.Type})
+		}
+	}
+	return match("metrics provider", ref, l.providers[pageID])
+}
+
+// pageAccessUserID returns the identifier of the page access user of a page identified by ref or
+// with the email address ref. Users are searched by email rather than listed.
+func (l *lookupCache) pageAccessUserID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	key := pageID + "/" + ref
+	if _, ok := l.users[key]; !ok {
+		if found, err := l.exists("page access user/"+key, ref, func() error {
+			_, err := l.client.GetPageAccessUser(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		users, err := l.client.FindPageAccessUsers(pageID, ref)
+		if err != nil {
+			return "", err
+		}
+		l.users[key] = []named{}
+		for _, u := range users {
+			l.users[key] = append(l.users[key], named{u.ID, u.Email})
+		}
+	}
+	return match("page access user", ref, l.users[key])
+}
+
+// pageAccessGroupID returns the identifier of the page access group of a page identified or named by ref.
+func (l *lookupCache) pageAccessGroupID(pageID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	if _, ok := l.accessGroups[pageID]; !ok {
+		if found, err := l.exists("page access group/"+pageID+"/"+ref, ref, func() error {
+			_, err := l.client.GetPageAccessGroup(pageID, ref)
+			return err
+		}); found || err != nil {
+			return ref, err
+		}
+
+		groups, err := l.client.ListPageAccessGroups(pageID, &client.ListOptions{All: true})
+		if err != nil {
+			return "", err
+		}
+		l.accessGroups[pageID] = []named{}
+		for _, g := range groups {
+			l.accessGroups[pageID] = append(l.accessGroups[pageID], named{g.ID, g.Name})
+		}
+	}
+	return match("page access group", ref, l.accessGroups[pageID])
+}
+
+// pageAccessGroupIDList returns the identifiers of a list of page access groups identified or named by refs.
+func (l *lookupCache) pageAccessGroupIDList(pageID string, refs []string) ([]string, error) {
+	ids := []string{}
+	for _, ref := range refs {
+		id, err := l.pageAccessGroupID(pageID, ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// metricIDList returns the identifiers of a list of metrics identified or named by refs.
+func (l *lookupCache) metricIDList(pageID string, refs []string) ([]string, error) {
+	ids := []string{}
+	for _, ref := range refs {
+		id, err := l.metricID(pageID, ref)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// user returns the user of an organization identified by ref or with the email address ref. Users
+// cannot be fetched one at a time, so they are always listed.
+func (l *lookupCache) user(organizationID, ref string) (*client.User, error) {
+	if _, ok := l.orgUsers[organizationID]; !ok {
+		users, err := l.client.ListUsers(organizationID, &client.ListOptions{All: true})
+		if err != nil {
+			return nil, err
+		}
+		l.orgUsers[organizationID] = users
+	}
+
+	candidates := []named{}
+	for _, u := range l.orgUsers[organizationID] {
+		candidates = append(candidates, named{u.ID, u.Email})
+	}
+	id, err := match("user", ref, candidates)
+	if err != nil {
+		return nil, err
+	}
+	for i, u := range l.orgUsers[organizationID] {
+		if u.ID == id {
+			return &l.orgUsers[organizationID][i], nil
+		}
+	}
+	return nil, fmt.Errorf("no user with the identifier or email address %q", ref)
+}
+
+// userID returns the identifier of the user of an organization identified by ref or with the email
+// address ref.
+func (l *lookupCache) userID(organizationID, ref string) (string, error) {
+	if ref == "" {
+		return ref, nil
+	}
+
+	user, err := l.user(organizationID, ref)
+	if err != nil {
+		return "", err
+	}
+	return user.ID, nil
+}
+
+// exists reports whether ref is the identifier of a resource, fetching it with get. References that
+// do not look like identifiers are not fetched, and a reference that is not found is looked up by
+// name by the caller. The result is cached under key for the rest of the invocation.
+func (l *lookupCache) exists(key, ref string, get func() error) (bool, error) {
+	if !idPattern.MatchString(ref) {
+		return false, nil
+	}
+	if found, ok := l.found[key]; ok {
+		return found, nil
+	}
+
+	err := get()
+	var apiErr *client.APIError
+	if errors.As(err, &apiErr) && apiErr.IsNotFound() {
+		l.found[key] = false
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	l.found[key] = true
+	return true, nil
+}
+
+// match returns the identifier of the candidate identified by ref, or else named ref exactly, or
+// else the only candidate named ref ignoring case.
+func match(kind, ref string, candidates []named) (string, error) {
+	var exact, folded []named
+	for _, c := range candidates {
+		if c.id == ref {
+			return c.id, nil
+		}
+		if c.name == ref {
+			exact = append(exact, c)
+		} else if strings.EqualFold(c.name, ref) {
+			folded = append(folded, c)
+		}
+	}
+
+	for _, matches := range [][]named{exact, folded} {
+		switch len(matches) {
+		case 0:
+			continue
+		case 1:
+			return matches[0].id, nil
+		default:
+			ids := []string{}
+			for _, m := range matches {
+				ids = append(ids, fmt.Sprintf("%s (%s)", m.id, m.name))
+			}
+			return "", fmt.Errorf("%s %q is ambiguous, use one of the identifiers: %s", kind, ref, strings.Join(ids, ", "))
+		}
+	}
+	return "", fmt.Errorf("no %s with the identifier or name %q", kind, ref)
+}
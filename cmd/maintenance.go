@@ -0,0 +1,260 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"time"
+)
+
+var maintenanceID string
+var maintenanceName string
+var maintenanceStatus string
+var maintenanceBody string
+var maintenanceStart string
+var maintenanceEnd string
+var maintenanceDuration time.Duration
+var maintenanceComponents map[string]string
+var maintenanceRemindPrior bool
+var maintenanceAutoInProgress bool
+var maintenanceAutoCompleted bool
+var maintenanceFilter string
+
+// maintenance and maintenances are scheduled maintenances, printed with their window in table output.
+type maintenance client.Incident
+type maintenances []client.Incident
+
+var getMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Get a list of scheduled maintenances or a scheduled maintenance with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if maintenanceID == "" {
+			each := c.EachScheduledMaintenances
+			switch maintenanceFilter {
+			case "scheduled":
+			case "upcoming":
+				each = c.EachUpcomingMaintenances
+			case "active":
+				each = c.EachActiveMaintenances
+			default:
+				cmd.Help()
+				os.Exit(1)
+			}
+			printList(func(fn func(maintenances) error) error {
+				return each(pageID, listOptions(), func(page []client.Incident) error {
+					return fn(maintenances(page))
+				})
+			})
+			return
+		}
+
+		var err error
+		maintenanceID, err = lookup(c).incidentID(pageID, maintenanceID)
+		exitOnError(err)
+
+		m, err := c.GetIncident(pageID, maintenanceID)
+		exitOnError(err)
+		printOutput((*maintenance)(m))
+	},
+}
+
+var createMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Schedule a maintenance.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		start, end, err := maintenanceWindow(cmd, nil)
+		exitOnError(err)
+		if start.Before(time.Now().Add(-time.Minute)) {
+			exitOnError(fmt.Errorf("the maintenance cannot start in the past, it starts at %s", start.Format(time.RFC3339)))
+		}
+
+		components, err := lookup(c).componentIDs(pageID, maintenanceComponents)
+		exitOnError(err)
+
+		m, err := createIncident(c, pageID, client.IncidentParams{
+			Name:                    &maintenanceName,
+			Status:                  client.String("scheduled"),
+			Body:                    changedString(cmd, "body", maintenanceBody),
+			ComponentIDs:            componentIDs(components),
+			Components:              components,
+			ScheduledFor:            &start,
+			ScheduledUntil:          &end,
+			ScheduledRemindPrior:    &maintenanceRemindPrior,
+			ScheduledAutoInProgress: &maintenanceAutoInProgress,
+			ScheduledAutoCompleted:  &maintenanceAutoCompleted,
+		})
+		exitOnError(err)
+		printOutput((*maintenance)(m))
+	},
+}
+
+var updateMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Update a scheduled maintenance.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if cmd.Flags().Changed("status") && (utils.Contains(client.MaintenanceStatuses, maintenanceStatus)) != true {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		var err error
+		maintenanceID, err = lookup(c).incidentID(pageID, maintenanceID)
+		exitOnError(err)
+
+		params := client.IncidentParams{
+			Name:                    changedString(cmd, "name", maintenanceName),
+			Status:                  changedString(cmd, "status", maintenanceStatus),
+			Body:                    changedString(cmd, "body", maintenanceBody),
+			ScheduledRemindPrior:    changedBool(cmd, "remind-prior", maintenanceRemindPrior),
+			ScheduledAutoInProgress: changedBool(cmd, "auto-in-progress", maintenanceAutoInProgress),
+			ScheduledAutoCompleted:  changedBool(cmd, "auto-completed", maintenanceAutoCompleted),
+		}
+
+		if cmd.Flags().Changed("start") || cmd.Flags().Changed("end") || cmd.Flags().Changed("duration") {
+			current, err := c.GetIncident(pageID, maintenanceID)
+			exitOnError(err)
+
+			start, end, err := maintenanceWindow(cmd, current)
+			exitOnError(err)
+			params.ScheduledFor = &start
+			params.ScheduledUntil = &end
+		}
+
+		params.Components, err = lookup(c).componentIDs(pageID, maintenanceComponents)
+		exitOnError(err)
+		params.ComponentIDs = componentIDs(params.Components)
+
+		m, err := updateIncident(c, pageID, maintenanceID, params)
+		exitOnError(err)
+		printOutput((*maintenance)(m))
+	},
+}
+
+var deleteMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Delete a scheduled maintenance with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		maintenanceID, err = lookup(c).incidentID(pageID, maintenanceID)
+		exitOnError(err)
+
+		m, err := c.DeleteIncident(pageID, maintenanceID)
+		exitOnError(err)
+		printOutput((*maintenance)(m))
+	},
+}
+
+// maintenanceWindow returns the start and end of a maintenance from --start and either --end or
+// --duration. When updating, current is the maintenance being changed and provides the part of
+// the window that is not set on the command line.
+func maintenanceWindow(cmd *cobra.Command, current *client.Incident) (time.Time, time.Time, error) {
+	var start, end time.Time
+	now := time.Now()
+
+	if cmd.Flags().Changed("end") && cmd.Flags().Changed("duration") {
+		return start, end, errors.New("only one of --end and --duration can be set")
+	}
+
+	if cmd.Flags().Changed("start") {
+		var err error
+		if start, err = utils.ParseTime(maintenanceStart, now); err != nil {
+			return start, end, err
+		}
+	} else if current != nil && current.ScheduledFor != nil {
+		start = *current.ScheduledFor
+	} else {
+		return start, end, errors.New("the start of the maintenance must be set with --start")
+	}
+
+	switch {
+	case cmd.Flags().Changed("end"):
+		var err error
+		if end, err = utils.ParseTime(maintenanceEnd, now); err != nil {
+			return start, end, err
+		}
+	case cmd.Flags().Changed("duration"):
+		end = start.Add(maintenanceDuration)
+	case current != nil && current.ScheduledUntil != nil:
+		end = *current.ScheduledUntil
+	default:
+		return start, end, errors.New("the end of the maintenance must be set with --end or --duration")
+	}
+
+	if !end.After(start) {
+		return start, end, fmt.Errorf("the maintenance must end after it starts, it starts at %s and ends at %s", start.Format(time.RFC3339), end.Format(time.RFC3339))
+	}
+	return start, end, nil
+}
+
+func init() {
+	getMaintenanceCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getMaintenanceCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getMaintenanceCmd.Flags().StringVarP(&maintenanceID, "id", "i", "", "Scheduled maintenance identifier or name")
+	getMaintenanceCmd.Flags().StringVarP(&maintenanceFilter, "filter", "f", "scheduled", "Scheduled maintenances to list: scheduled lists those in the scheduled state, upcoming those that have not started yet and active those in progress or being verified. Valid choices are: scheduled, upcoming, active")
+	getMaintenanceCmd.MarkFlagRequired("page-id")
+	addListFlags(getMaintenanceCmd)
+	createMaintenanceCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createMaintenanceCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createMaintenanceCmd.Flags().StringVarP(&maintenanceName, "name", "n", "", "Scheduled maintenance name (required)")
+	createMaintenanceCmd.Flags().StringVarP(&maintenanceBody, "body", "b", "", "The initial message, created as the first update")
+	createMaintenanceCmd.Flags().StringVar(&maintenanceStart, "start", "", "Start of the maintenance, e.g. \"2020-06-01 02:00\", \"tomorrow 02:00 Europe/London\" or \"+2h\" (required)")
+	createMaintenanceCmd.Flags().StringVar(&maintenanceEnd, "end", "", "End of the maintenance, in the same formats as --start")
+	createMaintenanceCmd.Flags().DurationVar(&maintenanceDuration, "duration", 0, "Duration of the maintenance, e.g. 2h or 90m, instead of --end")
+	createMaintenanceCmd.Flags().StringToStringVarP(&maintenanceComponents, "components", "c", map[string]string{}, "Map of status changes to apply to affected components, keyed by component identifier or name")
+	createMaintenanceCmd.Flags().BoolVar(&maintenanceRemindPrior, "remind-prior", false, "Remind subscribers 60 minutes before the maintenance starts")
+	createMaintenanceCmd.Flags().BoolVar(&maintenanceAutoInProgress, "auto-in-progress", true, "Move the maintenance to in_progress when it starts")
+	createMaintenanceCmd.Flags().BoolVar(&maintenanceAutoCompleted, "auto-completed", true, "Move the maintenance to completed when it ends")
+	createMaintenanceCmd.MarkFlagRequired("page-id")
+	createMaintenanceCmd.MarkFlagRequired("name")
+	createMaintenanceCmd.MarkFlagRequired("start")
+	updateMaintenanceCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updateMaintenanceCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updateMaintenanceCmd.Flags().StringVarP(&maintenanceID, "id", "i", "", "Scheduled maintenance identifier or name (required)")
+	updateMaintenanceCmd.Flags().StringVarP(&maintenanceName, "name", "n", "", "Scheduled maintenance name")
+	updateMaintenanceCmd.Flags().StringVarP(&maintenanceStatus, "status", "s", "", "The scheduled maintenance status. Valid choices are: scheduled, in_progress, verifying, completed.")
+	updateMaintenanceCmd.Flags().StringVarP(&maintenanceBody, "body", "b", "", "The message, created as a new update")
+	updateMaintenanceCmd.Flags().StringVar(&maintenanceStart, "start", "", "Start of the maintenance, e.g. \"2020-06-01 02:00\", \"tomorrow 02:00 Europe/London\" or \"+2h\"")
+	updateMaintenanceCmd.Flags().StringVar(&maintenanceEnd, "end", "", "End of the maintenance, in the same formats as --start")
+	updateMaintenanceCmd.Flags().DurationVar(&maintenanceDuration, "duration", 0, "Duration of the maintenance, e.g. 2h or 90m, instead of --end")
+	updateMaintenanceCmd.Flags().StringToStringVarP(&maintenanceComponents, "components", "c", map[string]string{}, "Map of status changes to apply to affected components, keyed by component identifier or name")
+	updateMaintenanceCmd.Flags().BoolVar(&maintenanceRemindPrior, "remind-prior", false, "Remind subscribers 60 minutes before the maintenance starts")
+	updateMaintenanceCmd.Flags().BoolVar(&maintenanceAutoInProgress, "auto-in-progress", true, "Move the maintenance to in_progress when it starts")
+	updateMaintenanceCmd.Flags().BoolVar(&maintenanceAutoCompleted, "auto-completed", true, "Move the maintenance to completed when it ends")
+	updateMaintenanceCmd.MarkFlagRequired("page-id")
+	updateMaintenanceCmd.MarkFlagRequired("id")
+	deleteMaintenanceCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deleteMaintenanceCmd.Flags().StringVarP(&maintenanceID, "id", "i", "", "Scheduled maintenance identifier or name (required)")
+	deleteMaintenanceCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	deleteMaintenanceCmd.MarkFlagRequired("id")
+	deleteMaintenanceCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getMaintenanceCmd)
+	createCmd.AddCommand(createMaintenanceCmd)
+	updateCmd.AddCommand(updateMaintenanceCmd)
+	deleteCmd.AddCommand(deleteMaintenanceCmd)
+}
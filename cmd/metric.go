@@ -0,0 +1,329 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var metricID string
+var metricProvider string
+var metricName string
+var metricIdentifier string
+var metricTransform string
+var metricSuffix string
+var metricDisplay bool
+var metricTooltipDescription string
+var metricDecimalPlaces int
+var metricYAxisMin float64
+var metricYAxisMax float64
+var metricYAxisHidden bool
+var metricValue float64
+var metricTimestamp string
+var metricFile string
+var metricBatchSize int
+
+var metricCmd = &cobra.Command{
+	Use:   "metric",
+	Short: "Submits data points to metrics",
+}
+
+var getMetricCmd = &cobra.Command{
+	Use:   "metric",
+	Short: "Get a list of metrics or a metric with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if metricID == "" {
+			var metrics []client.Metric
+			var err error
+			if metricProvider != "" {
+				metricProvider, err = lookup(c).metricsProviderID(pageID, metricProvider)
+				exitOnError(err)
+				metrics, err = c.ListProviderMetrics(pageID, metricProvider, listOptions())
+			} else {
+				metrics, err = c.ListMetrics(pageID, listOptions())
+			}
+			exitOnError(err)
+			printOutput(metrics)
+			return
+		}
+
+		var err error
+		metricID, err = lookup(c).metricID(pageID, metricID)
+		exitOnError(err)
+
+		metric, err := c.GetMetric(pageID, metricID)
+		exitOnError(err)
+		printOutput(metric)
+	},
+}
+
+var getMetricsProviderCmd = &cobra.Command{
+	Use:     "metric-provider",
+	Aliases: []string{"metrics-provider"},
+	Short:   "Get a list of metrics providers or a metrics provider with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if metricProvider == "" {
+			providers, err := c.ListMetricsProviders(pageID, listOptions())
+			exitOnError(err)
+			printOutput(providers)
+			return
+		}
+
+		var err error
+		metricProvider, err = lookup(c).metricsProviderID(pageID, metricProvider)
+		exitOnError(err)
+
+		provider, err := c.GetMetricsProvider(pageID, metricProvider)
+		exitOnError(err)
+		printOutput(provider)
+	},
+}
+
+var createMetricCmd = &cobra.Command{
+	Use:   "metric",
+	Short: "Create a metric.",
+	Long: `Create a metric fed by a metrics provider. Metrics of the Self provider are fed with
+'statuspage metric push', the others by the monitoring service of their provider, which needs
+--metric-identifier and --transform.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if cmd.Flags().Changed("transform") && (utils.Contains(client.MetricTransforms, metricTransform)) != true {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		var err error
+		metricProvider, err = lookup(c).metricsProviderID(pageID, metricProvider)
+		exitOnError(err)
+
+		metric, err := c.CreateMetric(pageID, metricProvider, client.MetricParams{
+			Name:               &metricName,
+			MetricIdentifier:   changedString(cmd, "metric-identifier", metricIdentifier),
+			Transform:          changedString(cmd, "transform", metricTransform),
+			Suffix:             changedString(cmd, "suffix", metricSuffix),
+			Display:            changedBool(cmd, "display", metricDisplay),
+			TooltipDescription: changedString(cmd, "tooltip-description", metricTooltipDescription),
+			DecimalPlaces:      changedInt(cmd, "decimal-places", metricDecimalPlaces),
+			YAxisMin:           changedFloat(cmd, "y-axis-min", metricYAxisMin),
+			YAxisMax:           changedFloat(cmd, "y-axis-max", metricYAxisMax),
+			YAxisHidden:        changedBool(cmd, "y-axis-hidden", metricYAxisHidden),
+		})
+		exitOnError(err)
+		printOutput(metric)
+	},
+}
+
+var pushMetricCmd = &cobra.Command{
+	Use:   "push",
+	Short: "Submit data points to metrics of the Self provider.",
+	Long: `Submit a data point to a metric with --metric-id and --value, at the time given with --timestamp
+or now. Timestamps are seconds since the Unix epoch or times such as 2020-06-01T12:00:00Z or "today 12:00 UTC".
+
+Data points can also be read from a CSV file with --file, whose first row names the columns metric_id,
+timestamp and value. The metric_id column can be left out when --metric-id is set. The data points
+are sent in batches of --batch-size, once the whole file has been read.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if metricFile == "" {
+			if metricID == "" || !cmd.Flags().Changed("value") {
+				exitOnError(errors.New("--metric-id and --value must be set unless the data points are read from --file"))
+			}
+
+			timestamp := time.Now()
+			if metricTimestamp != "" {
+				var err error
+				timestamp, err = parseTimestamp(metricTimestamp)
+				exitOnError(err)
+			}
+
+			var err error
+			metricID, err = lookup(c).metricID(pageID, metricID)
+			exitOnError(err)
+
+			exitOnError(c.AddMetricPoint(pageID, metricID, client.MetricPoint{Timestamp: timestamp.Unix(), Value: metricValue}))
+			fmt.Println("1 data point sent to metric " + metricID)
+			return
+		}
+
+		if metricBatchSize < 1 {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		points, err := readMetricPoints(c, metricFile)
+		exitOnError(err)
+
+		sent := 0
+		metrics := map[string]bool{}
+		for start := 0; start < len(points); start += metricBatchSize {
+			end := start + metricBatchSize
+			if end > len(points) {
+				end = len(points)
+			}
+
+			batch := map[string][]client.MetricPoint{}
+			for _, p := range points[start:end] {
+				batch[p.metricID] = append(batch[p.metricID], p.point)
+				metrics[p.metricID] = true
+			}
+			exitOnError(c.AddMetricPoints(pageID, batch))
+
+			sent = end
+			fmt.Fprintf(os.Stderr, "%d of %d data points sent\n", sent, len(points))
+		}
+		fmt.Printf("%d data points sent to %d metrics\n", sent, len(metrics))
+	},
+}
+
+// metricPoint is a data point read from a CSV file, with the metric it belongs to.
+type metricPoint struct {
+	metricID string
+	point    client.MetricPoint
+}
+
+// readMetricPoints reads the data points of a CSV file, or of stdin when path is "-". Metrics are
+// referred to by identifier or name, or set with --metric-id when the file has no metric_id column.
+func readMetricPoints(c *client.Client, path string) ([]metricPoint, error) {
+	data, err := utils.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, errors.New("the CSV file is empty, its first row must name the columns")
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"timestamp", "value"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("the CSV file has no %s column", name)
+		}
+	}
+	if _, ok := columns["metric_id"]; !ok && metricID == "" {
+		return nil, errors.New("the CSV file has no metric_id column, set the metric with --metric-id")
+	}
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	points := []metricPoint{}
+	for i, record := range records[1:] {
+		// Rows are numbered as in the file, after the header.
+		row := i + 2
+
+		ref := field(record, "metric_id")
+		if ref == "" {
+			ref = metricID
+		}
+		id, err := lookup(c).metricID(pageID, ref)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", row, err)
+		}
+
+		timestamp, err := parseTimestamp(field(record, "timestamp"))
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %v", row, err)
+		}
+
+		value, err := strconv.ParseFloat(field(record, "value"), 64)
+		if err != nil {
+			return nil, fmt.Errorf("row %d: invalid value %q", row, field(record, "value"))
+		}
+
+		points = append(points, metricPoint{id, client.MetricPoint{Timestamp: timestamp.Unix(), Value: value}})
+	}
+	return points, nil
+}
+
+// parseTimestamp parses a data point timestamp, either seconds since the Unix epoch or a time
+// accepted by utils.ParseTime.
+func parseTimestamp(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	return utils.ParseTime(value, time.Now())
+}
+
+func init() {
+	getMetricCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getMetricCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getMetricCmd.Flags().StringVarP(&metricID, "id", "i", "", "Metric identifier or name")
+	getMetricCmd.Flags().StringVar(&metricProvider, "provider", "", "Only list the metrics of this metrics provider, given by identifier or type")
+	getMetricCmd.MarkFlagRequired("page-id")
+	addListFlags(getMetricCmd)
+	getMetricsProviderCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getMetricsProviderCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getMetricsProviderCmd.Flags().StringVarP(&metricProvider, "id", "i", "", "Metrics provider identifier or type")
+	getMetricsProviderCmd.MarkFlagRequired("page-id")
+	addListFlags(getMetricsProviderCmd)
+	createMetricCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createMetricCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createMetricCmd.Flags().StringVar(&metricProvider, "provider", "", "Identifier or type of the metrics provider feeding the metric, such as Self (required)")
+	createMetricCmd.Flags().StringVarP(&metricName, "name", "n", "", "Display name for the metric (required)")
+	createMetricCmd.Flags().StringVar(&metricIdentifier, "metric-identifier", "", "Identifier of the metric in the monitoring service of the provider")
+	createMetricCmd.Flags().StringVar(&metricTransform, "transform", "", "How data points are combined. Valid choices are: average, count, max, min, sum, response_time, uptime")
+	createMetricCmd.Flags().StringVar(&metricSuffix, "suffix", "", "Suffix of the values of the metric, such as ms")
+	createMetricCmd.Flags().BoolVar(&metricDisplay, "display", false, "Show the metric on the page")
+	createMetricCmd.Flags().StringVar(&metricTooltipDescription, "tooltip-description", "", "Description shown in the tooltip of the metric")
+	createMetricCmd.Flags().IntVar(&metricDecimalPlaces, "decimal-places", 0, "Number of decimal places shown")
+	createMetricCmd.Flags().Float64Var(&metricYAxisMin, "y-axis-min", 0, "Lowest value of the y axis")
+	createMetricCmd.Flags().Float64Var(&metricYAxisMax, "y-axis-max", 0, "Highest value of the y axis")
+	createMetricCmd.Flags().BoolVar(&metricYAxisHidden, "y-axis-hidden", false, "Hide the y axis")
+	createMetricCmd.MarkFlagRequired("page-id")
+	createMetricCmd.MarkFlagRequired("provider")
+	createMetricCmd.MarkFlagRequired("name")
+	pushMetricCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	pushMetricCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	pushMetricCmd.Flags().StringVar(&metricID, "metric-id", "", "Identifier or name of the metric")
+	pushMetricCmd.Flags().Float64Var(&metricValue, "value", 0, "Value of the data point")
+	pushMetricCmd.Flags().StringVar(&metricTimestamp, "timestamp", "", "Time of the data point (default is now)")
+	pushMetricCmd.Flags().StringVarP(&metricFile, "file", "f", "", "CSV file to read data points from, - to read them from stdin")
+	pushMetricCmd.Flags().IntVar(&metricBatchSize, "batch-size", 100, "Number of data points sent per request with --file")
+	pushMetricCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getMetricCmd)
+	getCmd.AddCommand(getMetricsProviderCmd)
+	createCmd.AddCommand(createMetricCmd)
+	metricCmd.AddCommand(pushMetricCmd)
+	rootCmd.AddCommand(metricCmd)
+}
@@ -0,0 +1,359 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../jsonpath"
+	"encoding/json"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+)
+
+var output string
+
+// outputFormats are the values accepted by --output. jsonpath and go-template take their expression after an equals sign.
+var outputFormats = []string{"json", "yaml", "table", "name", "jsonpath=", "go-template="}
+
+// validateOutput returns an error when --output is not a supported format.
+func validateOutput() error {
+	for _, format := range outputFormats {
+		if output == format || (strings.HasSuffix(format, "=") && strings.HasPrefix(output, format)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported output format %q. Valid choices are: %s", output, strings.Join(outputFormats, ", "))
+}
+
+// printOutput prints v in the format selected with --output.
+func printOutput(v interface{}) {
+	exitOnError(writeOutput(os.Stdout, v))
+}
+
+func writeOutput(w io.Writer, v interface{}) error {
+	switch {
+	case output == "json":
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(w, string(out))
+		return nil
+	case output == "yaml":
+		data, err := toGeneric(v)
+		if err != nil {
+			return err
+		}
+		out, err := yaml.Marshal(data)
+		if err != nil {
+			return err
+		}
+		fmt.Fprint(w, string(out))
+		return nil
+	case output == "name":
+		return writeNames(w, v)
+	case output == "table":
+		return writeTable(w, v)
+	case strings.HasPrefix(output, "jsonpath="):
+		return writeJSONPath(w, v, strings.TrimPrefix(output, "jsonpath="))
+	case strings.HasPrefix(output, "go-template="):
+		return writeTemplate(w, v, strings.TrimPrefix(output, "go-template="))
+	}
+	return validateOutput()
+}
+
+// toGeneric converts v into the maps and slices produced by decoding its JSON form,
+// so templates and paths refer to fields by their API names.
+func toGeneric(v interface{}) (interface{}, error) {
+	out, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(out, &data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+func writeNames(w io.Writer, v interface{}) error {
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	items, ok := data.([]interface{})
+	if !ok {
+		items = []interface{}{data}
+	}
+
+	for _, item := range items {
+		if object, ok := item.(map[string]interface{}); ok {
+			if id, ok := object["id"]; ok {
+				fmt.Fprintln(w, id)
+			}
+		}
+	}
+	return nil
+}
+
+func writeJSONPath(w io.Writer, v interface{}, expression string) error {
+	if !strings.Contains(expression, "{") {
+		expression = "{" + expression + "}"
+	}
+
+	parser, err := jsonpath.Parse(expression)
+	if err != nil {
+		return fmt.Errorf("error parsing jsonpath %s: %v", expression, err)
+	}
+
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	if err := parser.Execute(w, data); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+func writeTemplate(w io.Writer, v interface{}, text string) error {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return fmt.Errorf("error parsing go-template %s: %v", text, err)
+	}
+
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	if err := tmpl.Execute(w, data); err != nil {
+		return err
+	}
+	fmt.Fprintln(w)
+	return nil
+}
+
+func writeTable(w io.Writer, v interface{}) error {
+	header, rows, err := tableRows(v)
+	if err != nil {
+		return err
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	return tw.Flush()
+}
+
+// tableRows returns the columns shown by the table output for each resource type.
+func tableRows(v interface{}) ([]string, [][]string, error) {
+	var rows [][]string
+
+	switch t := v.(type) {
+	case *client.Page:
+		return tableRows([]client.Page{*t})
+	case []client.Page:
+		for _, p := range t {
+			rows = append(rows, []string{p.ID, p.Name, p.Subdomain, formatTime(p.UpdatedAt)})
+		}
+		return []string{"ID", "NAME", "SUBDOMAIN", "UPDATED"}, rows, nil
+	case *client.Component:
+		return tableRows([]client.Component{*t})
+	case []client.Component:
+		for _, c := range t {
+			rows = append(rows, []string{c.ID, c.Name, c.Status, formatTime(c.UpdatedAt)})
+		}
+		return []string{"ID", "NAME", "STATUS", "UPDATED"}, rows, nil
+	case componentTree:
+		return []string{"ID", "NAME", "STATUS", "UPDATED"}, componentTreeRows(t), nil
+	case *client.ComponentGroup:
+		return tableRows([]client.ComponentGroup{*t})
+	case []client.ComponentGroup:
+		for _, g := range t {
+			rows = append(rows, []string{g.ID, g.Name, strconv.Itoa(len(g.Components)), formatTime(g.UpdatedAt)})
+		}
+		return []string{"ID", "NAME", "COMPONENTS", "UPDATED"}, rows, nil
+	case *client.Incident:
+		return tableRows([]client.Incident{*t})
+	case []client.Incident:
+		for _, i := range t {
+			rows = append(rows, []string{i.ID, i.Name, i.Status, i.Impact, formatTime(i.UpdatedAt)})
+		}
+		return []string{"ID", "NAME", "STATUS", "IMPACT", "UPDATED"}, rows, nil
+	case *client.IncidentUpdate:
+		return tableRows([]client.IncidentUpdate{*t})
+	case []client.IncidentUpdate:
+		for _, u := range t {
+			displayAt := u.CreatedAt
+			if u.DisplayAt != nil {
+				displayAt = *u.DisplayAt
+			}
+			rows = append(rows, []string{u.ID, u.Status, formatTime(displayAt), truncate(u.Body, 60)})
+		}
+		return []string{"ID", "STATUS", "DISPLAYED", "BODY"}, rows, nil
+	case *client.IncidentTemplate:
+		return tableRows([]client.IncidentTemplate{*t})
+	case []client.IncidentTemplate:
+		for _, i := range t {
+			rows = append(rows, []string{i.ID, i.Name, i.UpdateStatus, truncate(i.Title, 60)})
+		}
+		return []string{"ID", "NAME", "STATUS", "TITLE"}, rows, nil
+	case *client.Metric:
+		return tableRows([]client.Metric{*t})
+	case []client.Metric:
+		for _, m := range t {
+			rows = append(rows, []string{m.ID, m.Name, m.Suffix, strconv.FormatBool(m.Display), formatTimePtr(m.MostRecentDataAt)})
+		}
+		return []string{"ID", "NAME", "SUFFIX", "DISPLAYED", "LAST DATA"}, rows, nil
+	case *client.MetricsProvider:
+		return tableRows([]client.MetricsProvider{*t})
+	case []client.MetricsProvider:
+		for _, p := range t {
+			rows = append(rows, []string{p.ID, p.Type, strconv.FormatBool(p.Disabled), formatTime(p.UpdatedAt)})
+		}
+		return []string{"ID", "TYPE", "DISABLED", "UPDATED"}, rows, nil
+	case *client.PageAccessUser:
+		return tableRows([]client.PageAccessUser{*t})
+	case []client.PageAccessUser:
+		for _, u := range t {
+			rows = append(rows, []string{u.ID, u.Email, strconv.Itoa(len(u.PageAccessGroupIDs)), formatTime(u.UpdatedAt)})
+		}
+		return []string{"ID", "EMAIL", "GROUPS", "UPDATED"}, rows, nil
+	case *client.PageAccessGroup:
+		return tableRows([]client.PageAccessGroup{*t})
+	case []client.PageAccessGroup:
+		for _, g := range t {
+			rows = append(rows, []string{g.ID, g.Name, strconv.Itoa(len(g.PageAccessUserIDs)), strconv.Itoa(len(g.ComponentIDs)), strconv.Itoa(len(g.MetricIDs)), formatTime(g.UpdatedAt)})
+		}
+		return []string{"ID", "NAME", "USERS", "COMPONENTS", "METRICS", "UPDATED"}, rows, nil
+	case *client.User:
+		return tableRows([]client.User{*t})
+	case []client.User:
+		for _, u := range t {
+			rows = append(rows, []string{u.ID, u.Email, strings.TrimSpace(u.FirstName + " " + u.LastName), formatTime(u.CreatedAt)})
+		}
+		return []string{"ID", "EMAIL", "NAME", "CREATED"}, rows, nil
+	case *client.Permissions:
+		for _, p := range t.Pages {
+			rows = append(rows, []string{p.PageID, strconv.FormatBool(p.PageConfiguration), strconv.FormatBool(p.IncidentManager), strconv.FormatBool(p.MaintenanceManager)})
+		}
+		return []string{"PAGE", "PAGE CONFIGURATION", "INCIDENT MANAGER", "MAINTENANCE MANAGER"}, rows, nil
+	case *client.Subscriber:
+		return tableRows([]client.Subscriber{*t})
+	case []client.Subscriber:
+		for _, sub := range t {
+			rows = append(rows, []string{sub.ID, sub.Mode, subscriberContact(sub), strconv.Itoa(len(sub.Components)), formatTime(sub.CreatedAt)})
+		}
+		return []string{"ID", "TYPE", "CONTACT", "COMPONENTS", "CREATED"}, rows, nil
+	case *client.Postmortem:
+		status := "draft"
+		if t.PublishedAt != nil {
+			status = "published"
+		}
+		rows = append(rows, []string{status, formatTimePtr(t.PublishedAt), formatTimePtr(t.BodyDraftUpdatedAt), truncate(t.BodyDraft, 60)})
+		return []string{"STATUS", "PUBLISHED", "DRAFT UPDATED", "DRAFT"}, rows, nil
+	case *maintenance:
+		return tableRows(maintenances{client.Incident(*t)})
+	case maintenances:
+		for _, m := range t {
+			rows = append(rows, []string{m.ID, m.Name, m.Status, formatTimePtr(m.ScheduledFor), formatTimePtr(m.ScheduledUntil)})
+		}
+		return []string{"ID", "NAME", "STATUS", "STARTS", "ENDS"}, rows, nil
+	}
+
+	return nil, nil, fmt.Errorf("table output is not supported for %T", v)
+}
+
+// componentTreeRows returns a row for every component group followed by rows for its components,
+// in the order they are shown on the page.
+func componentTreeRows(components []client.Component) [][]string {
+	sorted := append([]client.Component{}, components...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Position < sorted[j].Position })
+
+	groups := map[string]bool{}
+	children := map[string][]client.Component{}
+	for _, c := range sorted {
+		if c.Group {
+			groups[c.ID] = true
+		}
+	}
+
+	var top []client.Component
+	for _, c := range sorted {
+		if c.GroupID != "" && groups[c.GroupID] {
+			children[c.GroupID] = append(children[c.GroupID], c)
+		} else {
+			top = append(top, c)
+		}
+	}
+
+	var rows [][]string
+	for _, c := range top {
+		if !c.Group {
+			rows = append(rows, []string{c.ID, c.Name, c.Status, formatTime(c.UpdatedAt)})
+			continue
+		}
+		rows = append(rows, []string{c.ID, c.Name, "", formatTime(c.UpdatedAt)})
+		for i, child := range children[c.ID] {
+			branch := "├── "
+			if i == len(children[c.ID])-1 {
+				branch = "└── "
+			}
+			rows = append(rows, []string{child.ID, branch + child.Name, child.Status, formatTime(child.UpdatedAt)})
+		}
+	}
+	return rows
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04")
+}
+
+func formatTimePtr(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return formatTime(*t)
+}
+
+// truncate shortens s to the first line and at most n characters for table output.
+func truncate(s string, n int) string {
+	if i := strings.IndexAny(s, "\r\n"); i >= 0 {
+		s = s[:i] + "..."
+	}
+	if runes := []rune(s); len(runes) > n {
+		s = string(runes[:n-3]) + "..."
+	}
+	return s
+}
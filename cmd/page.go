@@ -16,52 +16,170 @@ limitations under the License.
 package cmd
 
 import (
-	"../utils"
+	"../client"
 	"fmt"
 	"github.com/spf13/cobra"
-	"io/ioutil"
-	"net/http"
-	"os"
+	"regexp"
 	"time"
 )
 
+var pageName string
+var pageDomain string
+var pageSubdomain string
+var pageURL string
+var pageBranding string
+var pageSupportURL string
+var pageTimeZone string
+var pageAllowPageSubscribers bool
+var pageAllowIncidentSubscribers bool
+var pageAllowEmailSubscribers bool
+var pageAllowSMSSubscribers bool
+var pageAllowRSSAtomFeeds bool
+var pageAllowWebhookSubscribers bool
+var pageHiddenFromSearch bool
+var pageViewersMustBeTeamMembers bool
+var pageNotificationsFromEmail string
+var pageNotificationsEmailFooter string
+var pageCSSBodyBackgroundColor string
+var pageCSSFontColor string
+var pageCSSLightFontColor string
+var pageCSSGreens string
+var pageCSSYellows string
+var pageCSSOranges string
+var pageCSSBlues string
+var pageCSSReds string
+var pageCSSBorderColor string
+var pageCSSGraphColor string
+var pageCSSLinkColor string
+var pageCSSNoData string
+
+// hexColorPattern matches the hex colours, such as #2c3e50, accepted by the colour settings of pages.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+// pageColorFlags are the flags of update page setting colours.
+var pageColorFlags = []string{
+	"css-body-background-color", "css-font-color", "css-light-font-color", "css-greens", "css-yellows", "css-oranges",
+	"css-blues", "css-reds", "css-border-color", "css-graph-color", "css-link-color", "css-no-data",
+}
+
 var getPageCmd = &cobra.Command{
 	Use:   "page",
-	Short: "Get a list of pages.",
+	Short: "Get a list of pages or a page with a specified page identifier.",
 	Run: func(cmd *cobra.Command, args []string) {
-		apiKeyFromEnv := utils.GetEnv("API_KEY")
-		apiKey = utils.DefaultToEnv(apiKeyFromEnv, apiKey)
+		c := newClient()
 
-		client := &http.Client{Timeout: time.Second * 10}
-		request, err := http.NewRequest("GET", "https://api.statuspage.io/v1/pages", nil)
-		request.Header.Set("Authorization", "OAuth "+apiKey)
-
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+		if pageID == "" {
+			pages, err := c.ListPages()
+			exitOnError(err)
+			printOutput(pages)
+			return
 		}
 
-		resp, err := client.Do(request)
+		page, err := c.GetPage(pageID)
+		exitOnError(err)
+		printOutput(page)
+	},
+}
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
+var updatePageCmd = &cobra.Command{
+	Use:   "page",
+	Short: "Update the settings of a page.",
+	Run: func(cmd *cobra.Command, args []string) {
+		exitOnError(validatePageSettings(cmd))
 
-		defer resp.Body.Close()
+		c := newClient()
 
-		body, err := ioutil.ReadAll(resp.Body)
+		page, err := c.UpdatePage(pageID, client.PageParams{
+			Name:                     changedString(cmd, "name", pageName),
+			Domain:                   changedString(cmd, "domain", pageDomain),
+			Subdomain:                changedString(cmd, "subdomain", pageSubdomain),
+			URL:                      changedString(cmd, "url", pageURL),
+			Branding:                 changedString(cmd, "branding", pageBranding),
+			SupportURL:               changedString(cmd, "support-url", pageSupportURL),
+			TimeZone:                 changedString(cmd, "time-zone", pageTimeZone),
+			AllowPageSubscribers:     changedBool(cmd, "allow-page-subscribers", pageAllowPageSubscribers),
+			AllowIncidentSubscribers: changedBool(cmd, "allow-incident-subscribers", pageAllowIncidentSubscribers),
+			AllowEmailSubscribers:    changedBool(cmd, "allow-email-subscribers", pageAllowEmailSubscribers),
+			AllowSMSSubscribers:      changedBool(cmd, "allow-sms-subscribers", pageAllowSMSSubscribers),
+			AllowRSSAtomFeeds:        changedBool(cmd, "allow-rss-atom-feeds", pageAllowRSSAtomFeeds),
+			AllowWebhookSubscribers:  changedBool(cmd, "allow-webhook-subscribers", pageAllowWebhookSubscribers),
+			HiddenFromSearch:         changedBool(cmd, "hidden-from-search", pageHiddenFromSearch),
+			ViewersMustBeTeamMembers: changedBool(cmd, "viewers-must-be-team-members", pageViewersMustBeTeamMembers),
+			NotificationsFromEmail:   changedString(cmd, "notifications-from-email", pageNotificationsFromEmail),
+			NotificationsEmailFooter: changedString(cmd, "notifications-email-footer", pageNotificationsEmailFooter),
+			CSSBodyBackgroundColor:   changedString(cmd, "css-body-background-color", pageCSSBodyBackgroundColor),
+			CSSFontColor:             changedString(cmd, "css-font-color", pageCSSFontColor),
+			CSSLightFontColor:        changedString(cmd, "css-light-font-color", pageCSSLightFontColor),
+			CSSGreens:                changedString(cmd, "css-greens", pageCSSGreens),
+			CSSYellows:               changedString(cmd, "css-yellows", pageCSSYellows),
+			CSSOranges:               changedString(cmd, "css-oranges", pageCSSOranges),
+			CSSBlues:                 changedString(cmd, "css-blues", pageCSSBlues),
+			CSSReds:                  changedString(cmd, "css-reds", pageCSSReds),
+			CSSBorderColor:           changedString(cmd, "css-border-color", pageCSSBorderColor),
+			CSSGraphColor:            changedString(cmd, "css-graph-color", pageCSSGraphColor),
+			CSSLinkColor:             changedString(cmd, "css-link-color", pageCSSLinkColor),
+			CSSNoData:                changedString(cmd, "css-no-data", pageCSSNoData),
+		})
+		exitOnError(err)
+		printOutput(page)
+	},
+}
 
-		if err != nil {
-			fmt.Println(err)
-			os.Exit(1)
+// validatePageSettings checks the colours and time zone set with update page before they are sent.
+func validatePageSettings(cmd *cobra.Command) error {
+	for _, name := range pageColorFlags {
+		if !cmd.Flags().Changed(name) {
+			continue
 		}
+		value, _ := cmd.Flags().GetString(name)
+		if !hexColorPattern.MatchString(value) {
+			return fmt.Errorf("invalid colour %q for --%s, use a hex colour such as #2c3e50", value, name)
+		}
+	}
 
-		fmt.Println(string(body))
-	},
+	if cmd.Flags().Changed("time-zone") {
+		if _, err := time.LoadLocation(pageTimeZone); err != nil || pageTimeZone == "" || pageTimeZone == "Local" {
+			return fmt.Errorf("invalid time zone %q, use a time zone such as UTC or Europe/London", pageTimeZone)
+		}
+	}
+	return nil
 }
 
 func init() {
 	getPageCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getPageCmd.Flags().StringVarP(&pageID, "id", "i", "", "Page identifier or name")
+	updatePageCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updatePageCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updatePageCmd.Flags().StringVarP(&pageName, "name", "n", "", "Name of the page")
+	updatePageCmd.Flags().StringVar(&pageDomain, "domain", "", "Custom domain of the page, such as status.example.com")
+	updatePageCmd.Flags().StringVar(&pageSubdomain, "subdomain", "", "Subdomain of the page on statuspage.io")
+	updatePageCmd.Flags().StringVar(&pageURL, "url", "", "Website of the company, linked from the page")
+	updatePageCmd.Flags().StringVar(&pageBranding, "branding", "", "Branding of the page, either basic or premium")
+	updatePageCmd.Flags().StringVar(&pageSupportURL, "support-url", "", "Support website of the company, linked from the page")
+	updatePageCmd.Flags().StringVar(&pageTimeZone, "time-zone", "", "Time zone of the page, such as UTC or Europe/London")
+	updatePageCmd.Flags().BoolVar(&pageAllowPageSubscribers, "allow-page-subscribers", false, "Allow subscribing to the whole page")
+	updatePageCmd.Flags().BoolVar(&pageAllowIncidentSubscribers, "allow-incident-subscribers", false, "Allow subscribing to single incidents")
+	updatePageCmd.Flags().BoolVar(&pageAllowEmailSubscribers, "allow-email-subscribers", false, "Allow subscribing by email")
+	updatePageCmd.Flags().BoolVar(&pageAllowSMSSubscribers, "allow-sms-subscribers", false, "Allow subscribing by SMS")
+	updatePageCmd.Flags().BoolVar(&pageAllowRSSAtomFeeds, "allow-rss-atom-feeds", false, "Publish RSS and Atom feeds")
+	updatePageCmd.Flags().BoolVar(&pageAllowWebhookSubscribers, "allow-webhook-subscribers", false, "Allow subscribing with webhooks")
+	updatePageCmd.Flags().BoolVar(&pageHiddenFromSearch, "hidden-from-search", false, "Hide the page from search engines")
+	updatePageCmd.Flags().BoolVar(&pageViewersMustBeTeamMembers, "viewers-must-be-team-members", false, "Only show the page to team members")
+	updatePageCmd.Flags().StringVar(&pageNotificationsFromEmail, "notifications-from-email", "", "Email address notifications are sent from")
+	updatePageCmd.Flags().StringVar(&pageNotificationsEmailFooter, "notifications-email-footer", "", "Footer of notification emails")
+	updatePageCmd.Flags().StringVar(&pageCSSBodyBackgroundColor, "css-body-background-color", "", "Background colour of the page, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSFontColor, "css-font-color", "", "Colour of the text, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSLightFontColor, "css-light-font-color", "", "Colour of the light text, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSGreens, "css-greens", "", "Colour of operational components, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSYellows, "css-yellows", "", "Colour of components with degraded performance, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSOranges, "css-oranges", "", "Colour of components with a partial outage, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSBlues, "css-blues", "", "Colour of components under maintenance, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSReds, "css-reds", "", "Colour of components with a major outage, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSBorderColor, "css-border-color", "", "Colour of the borders, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSGraphColor, "css-graph-color", "", "Colour of the metric graphs, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSLinkColor, "css-link-color", "", "Colour of the links, as a hex colour")
+	updatePageCmd.Flags().StringVar(&pageCSSNoData, "css-no-data", "", "Colour of days without data, as a hex colour")
+	updatePageCmd.MarkFlagRequired("page-id")
 	getCmd.AddCommand(getPageCmd)
+	updateCmd.AddCommand(updatePageCmd)
 }
@@ -0,0 +1,214 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"github.com/spf13/cobra"
+)
+
+var pageAccessGroupID string
+var pageAccessGroupName string
+var pageAccessGroupExternalIdentifier string
+var pageAccessGroupComponents []string
+var pageAccessGroupMetrics []string
+var pageAccessGroupAddComponents []string
+var pageAccessGroupRemoveComponents []string
+var pageAccessGroupAddMetrics []string
+var pageAccessGroupRemoveMetrics []string
+
+var getPageAccessGroupCmd = &cobra.Command{
+	Use:     "page-access-group",
+	Aliases: []string{"page-access-groups"},
+	Short:   "Get a list of page access groups or a page access group with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if pageAccessGroupID == "" {
+			printList(func(fn func([]client.PageAccessGroup) error) error {
+				return c.EachPageAccessGroups(pageID, listOptions(), fn)
+			})
+			return
+		}
+
+		var err error
+		pageAccessGroupID, err = lookup(c).pageAccessGroupID(pageID, pageAccessGroupID)
+		exitOnError(err)
+
+		group, err := c.GetPageAccessGroup(pageID, pageAccessGroupID)
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+var createPageAccessGroupCmd = &cobra.Command{
+	Use:   "page-access-group",
+	Short: "Create a page access group seeing the given components and metrics.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		params, err := pageAccessGroupParams(cmd, c)
+		exitOnError(err)
+
+		group, err := c.CreatePageAccessGroup(pageID, params)
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+var updatePageAccessGroupCmd = &cobra.Command{
+	Use:   "page-access-group",
+	Short: "Update a page access group.",
+	Long: `Update a page access group. --components and --metrics replace the components and metrics the
+group can see, while --add-components, --remove-components, --add-metrics and --remove-metrics
+change them and keep the others.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if cmd.Flags().Changed("components") && (len(pageAccessGroupAddComponents) > 0 || len(pageAccessGroupRemoveComponents) > 0) {
+			exitOnError(errors.New("--components replaces the components of the group, it cannot be used with --add-components or --remove-components"))
+		}
+		if cmd.Flags().Changed("metrics") && (len(pageAccessGroupAddMetrics) > 0 || len(pageAccessGroupRemoveMetrics) > 0) {
+			exitOnError(errors.New("--metrics replaces the metrics of the group, it cannot be used with --add-metrics or --remove-metrics"))
+		}
+
+		c := newClient()
+
+		var err error
+		pageAccessGroupID, err = lookup(c).pageAccessGroupID(pageID, pageAccessGroupID)
+		exitOnError(err)
+
+		params, err := pageAccessGroupParams(cmd, c)
+		exitOnError(err)
+
+		addComponents, err := lookup(c).componentIDList(pageID, pageAccessGroupAddComponents)
+		exitOnError(err)
+		removeComponents, err := lookup(c).componentIDList(pageID, pageAccessGroupRemoveComponents)
+		exitOnError(err)
+
+		// Metrics cannot be added or removed one at a time, so the metrics of the group are
+		// replaced by its current metrics with the changes applied.
+		if len(pageAccessGroupAddMetrics) > 0 || len(pageAccessGroupRemoveMetrics) > 0 {
+			addMetrics, err := lookup(c).metricIDList(pageID, pageAccessGroupAddMetrics)
+			exitOnError(err)
+			removeMetrics, err := lookup(c).metricIDList(pageID, pageAccessGroupRemoveMetrics)
+			exitOnError(err)
+
+			current, err := c.GetPageAccessGroup(pageID, pageAccessGroupID)
+			exitOnError(err)
+			metrics := utils.Remove(utils.AppendMissing(current.MetricIDs, addMetrics...), removeMetrics...)
+			params.MetricIDs = &metrics
+		}
+
+		var group *client.PageAccessGroup
+		if params != (client.PageAccessGroupParams{}) {
+			group, err = c.UpdatePageAccessGroup(pageID, pageAccessGroupID, params)
+			exitOnError(err)
+		}
+		if len(addComponents) > 0 {
+			group, err = c.AddPageAccessGroupComponents(pageID, pageAccessGroupID, addComponents)
+			exitOnError(err)
+		}
+		for _, id := range removeComponents {
+			group, err = c.RemovePageAccessGroupComponent(pageID, pageAccessGroupID, id)
+			exitOnError(err)
+		}
+		if group == nil {
+			group, err = c.GetPageAccessGroup(pageID, pageAccessGroupID)
+			exitOnError(err)
+		}
+		printOutput(group)
+	},
+}
+
+var deletePageAccessGroupCmd = &cobra.Command{
+	Use:   "page-access-group",
+	Short: "Delete a page access group with a specified identifier. The users of the group are kept.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		pageAccessGroupID, err = lookup(c).pageAccessGroupID(pageID, pageAccessGroupID)
+		exitOnError(err)
+
+		group, err := c.DeletePageAccessGroup(pageID, pageAccessGroupID)
+		exitOnError(err)
+		printOutput(group)
+	},
+}
+
+// pageAccessGroupParams returns the fields of a page access group set on the command line, with
+// the components and metrics resolved to identifiers. Components and metrics are only set when
+// their flags are, so an empty list removes them all.
+func pageAccessGroupParams(cmd *cobra.Command, c *client.Client) (client.PageAccessGroupParams, error) {
+	params := client.PageAccessGroupParams{
+		Name:               changedString(cmd, "name", pageAccessGroupName),
+		ExternalIdentifier: changedString(cmd, "external-identifier", pageAccessGroupExternalIdentifier),
+	}
+	if cmd.Flags().Changed("components") {
+		components, err := lookup(c).componentIDList(pageID, pageAccessGroupComponents)
+		if err != nil {
+			return client.PageAccessGroupParams{}, err
+		}
+		params.ComponentIDs = &components
+	}
+	if cmd.Flags().Changed("metrics") {
+		metrics, err := lookup(c).metricIDList(pageID, pageAccessGroupMetrics)
+		if err != nil {
+			return client.PageAccessGroupParams{}, err
+		}
+		params.MetricIDs = &metrics
+	}
+	return params, nil
+}
+
+func init() {
+	getPageAccessGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getPageAccessGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getPageAccessGroupCmd.Flags().StringVarP(&pageAccessGroupID, "id", "i", "", "Page access group identifier or name")
+	getPageAccessGroupCmd.MarkFlagRequired("page-id")
+	addListFlags(getPageAccessGroupCmd)
+	createPageAccessGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createPageAccessGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createPageAccessGroupCmd.Flags().StringVarP(&pageAccessGroupName, "name", "n", "", "Name of the page access group (required)")
+	createPageAccessGroupCmd.Flags().StringVar(&pageAccessGroupExternalIdentifier, "external-identifier", "", "Identifier of the group with the identity provider of the page")
+	createPageAccessGroupCmd.Flags().StringSliceVarP(&pageAccessGroupComponents, "components", "c", []string{}, "Identifiers or names of the components the group can see")
+	createPageAccessGroupCmd.Flags().StringSliceVarP(&pageAccessGroupMetrics, "metrics", "m", []string{}, "Identifiers or names of the metrics the group can see")
+	createPageAccessGroupCmd.MarkFlagRequired("page-id")
+	createPageAccessGroupCmd.MarkFlagRequired("name")
+	updatePageAccessGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updatePageAccessGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updatePageAccessGroupCmd.Flags().StringVarP(&pageAccessGroupID, "id", "i", "", "Page access group identifier or name (required)")
+	updatePageAccessGroupCmd.Flags().StringVarP(&pageAccessGroupName, "name", "n", "", "Name of the page access group")
+	updatePageAccessGroupCmd.Flags().StringVar(&pageAccessGroupExternalIdentifier, "external-identifier", "", "Identifier of the group with the identity provider of the page")
+	updatePageAccessGroupCmd.Flags().StringSliceVarP(&pageAccessGroupComponents, "components", "c", []string{}, "Identifiers or names of the components the group can see, replacing the current components")
+	updatePageAccessGroupCmd.Flags().StringSliceVarP(&pageAccessGroupMetrics, "metrics", "m", []string{}, "Identifiers or names of the metrics the group can see, replacing the current metrics")
+	updatePageAccessGroupCmd.Flags().StringSliceVar(&pageAccessGroupAddComponents, "add-components", []string{}, "Identifiers or names of components the group can see from now on")
+	updatePageAccessGroupCmd.Flags().StringSliceVar(&pageAccessGroupRemoveComponents, "remove-components", []string{}, "Identifiers or names of components the group can no longer see")
+	updatePageAccessGroupCmd.Flags().StringSliceVar(&pageAccessGroupAddMetrics, "add-metrics", []string{}, "Identifiers or names of metrics the group can see from now on")
+	updatePageAccessGroupCmd.Flags().StringSliceVar(&pageAccessGroupRemoveMetrics, "remove-metrics", []string{}, "Identifiers or names of metrics the group can no longer see")
+	updatePageAccessGroupCmd.MarkFlagRequired("page-id")
+	updatePageAccessGroupCmd.MarkFlagRequired("id")
+	deletePageAccessGroupCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deletePageAccessGroupCmd.Flags().StringVarP(&pageAccessGroupID, "id", "i", "", "Page access group identifier or name (required)")
+	deletePageAccessGroupCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	deletePageAccessGroupCmd.MarkFlagRequired("id")
+	deletePageAccessGroupCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getPageAccessGroupCmd)
+	createCmd.AddCommand(createPageAccessGroupCmd)
+	updateCmd.AddCommand(updatePageAccessGroupCmd)
+	deleteCmd.AddCommand(deletePageAccessGroupCmd)
+}
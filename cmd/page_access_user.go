@@ -0,0 +1,153 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"fmt"
+	"github.com/spf13/cobra"
+)
+
+var pageAccessUserID string
+var pageAccessUserEmail string
+var pageAccessUserExternalLogin string
+var pageAccessUserGroups []string
+
+var getPageAccessUserCmd = &cobra.Command{
+	Use:     "page-access-user",
+	Aliases: []string{"page-access-users"},
+	Short:   "Get a list of page access users or a page access user with a specified identifier or email address.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if pageAccessUserID == "" {
+			printList(func(fn func([]client.PageAccessUser) error) error {
+				return c.EachPageAccessUsers(pageID, listOptions(), fn)
+			})
+			return
+		}
+
+		var err error
+		pageAccessUserID, err = lookup(c).pageAccessUserID(pageID, pageAccessUserID)
+		exitOnError(err)
+
+		user, err := c.GetPageAccessUser(pageID, pageAccessUserID)
+		exitOnError(err)
+		printOutput(user)
+	},
+}
+
+var createPageAccessUserCmd = &cobra.Command{
+	Use:   "page-access-user",
+	Short: "Give someone access to a private or audience-specific page.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		groups, err := pageAccessUserGroupIDs(cmd, c)
+		exitOnError(err)
+
+		user, err := c.CreatePageAccessUser(pageID, client.PageAccessUserParams{
+			Email:              &pageAccessUserEmail,
+			ExternalLogin:      changedString(cmd, "external-login", pageAccessUserExternalLogin),
+			PageAccessGroupIDs: groups,
+		})
+		exitOnError(err)
+		printOutput(user)
+	},
+}
+
+var updatePageAccessUserCmd = &cobra.Command{
+	Use:   "page-access-user",
+	Short: "Update a page access user.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		pageAccessUserID, err = lookup(c).pageAccessUserID(pageID, pageAccessUserID)
+		exitOnError(err)
+
+		groups, err := pageAccessUserGroupIDs(cmd, c)
+		exitOnError(err)
+
+		user, err := c.UpdatePageAccessUser(pageID, pageAccessUserID, client.PageAccessUserParams{
+			Email:              changedString(cmd, "email", pageAccessUserEmail),
+			ExternalLogin:      changedString(cmd, "external-login", pageAccessUserExternalLogin),
+			PageAccessGroupIDs: groups,
+		})
+		exitOnError(err)
+		printOutput(user)
+	},
+}
+
+var deletePageAccessUserCmd = &cobra.Command{
+	Use:   "page-access-user",
+	Short: "Remove the access of a page access user with a specified identifier or email address.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		pageAccessUserID, err = lookup(c).pageAccessUserID(pageID, pageAccessUserID)
+		exitOnError(err)
+
+		exitOnError(c.DeletePageAccessUser(pageID, pageAccessUserID))
+		fmt.Println("page access user " + pageAccessUserID + " deleted")
+	},
+}
+
+// pageAccessUserGroupIDs returns the identifiers of the page access groups set with --groups, or
+// nil when the flag is not set, so an empty list removes the user from every group.
+func pageAccessUserGroupIDs(cmd *cobra.Command, c *client.Client) (*[]string, error) {
+	if !cmd.Flags().Changed("groups") {
+		return nil, nil
+	}
+	groups, err := lookup(c).pageAccessGroupIDList(pageID, pageAccessUserGroups)
+	if err != nil {
+		return nil, err
+	}
+	return &groups, nil
+}
+
+func init() {
+	getPageAccessUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getPageAccessUserCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getPageAccessUserCmd.Flags().StringVarP(&pageAccessUserID, "id", "i", "", "Page access user identifier or email address")
+	getPageAccessUserCmd.MarkFlagRequired("page-id")
+	addListFlags(getPageAccessUserCmd)
+	createPageAccessUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createPageAccessUserCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createPageAccessUserCmd.Flags().StringVarP(&pageAccessUserEmail, "email", "e", "", "Email address of the user (required)")
+	createPageAccessUserCmd.Flags().StringVar(&pageAccessUserExternalLogin, "external-login", "", "Login of the user with the identity provider of the page")
+	createPageAccessUserCmd.Flags().StringSliceVarP(&pageAccessUserGroups, "groups", "g", []string{}, "Identifiers or names of the page access groups of the user")
+	createPageAccessUserCmd.MarkFlagRequired("page-id")
+	createPageAccessUserCmd.MarkFlagRequired("email")
+	updatePageAccessUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updatePageAccessUserCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updatePageAccessUserCmd.Flags().StringVarP(&pageAccessUserID, "id", "i", "", "Page access user identifier or email address (required)")
+	updatePageAccessUserCmd.Flags().StringVarP(&pageAccessUserEmail, "email", "e", "", "Email address of the user")
+	updatePageAccessUserCmd.Flags().StringVar(&pageAccessUserExternalLogin, "external-login", "", "Login of the user with the identity provider of the page")
+	updatePageAccessUserCmd.Flags().StringSliceVarP(&pageAccessUserGroups, "groups", "g", []string{}, "Identifiers or names of the page access groups of the user, replacing the current groups")
+	updatePageAccessUserCmd.MarkFlagRequired("page-id")
+	updatePageAccessUserCmd.MarkFlagRequired("id")
+	deletePageAccessUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deletePageAccessUserCmd.Flags().StringVarP(&pageAccessUserID, "id", "i", "", "Page access user identifier or email address (required)")
+	deletePageAccessUserCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	deletePageAccessUserCmd.MarkFlagRequired("id")
+	deletePageAccessUserCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getPageAccessUserCmd)
+	createCmd.AddCommand(createPageAccessUserCmd)
+	updateCmd.AddCommand(updatePageAccessUserCmd)
+	deleteCmd.AddCommand(deletePageAccessUserCmd)
+}
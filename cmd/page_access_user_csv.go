@@ -0,0 +1,198 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+)
+
+var pageAccessUserCSV string
+var pageAccessUserSyncGroup string
+var pageAccessUserPrune bool
+var pageAccessUserDryRun bool
+
+var importPageAccessUsersCmd = &cobra.Command{
+	Use:     "page-access-users",
+	Aliases: []string{"page-access-user"},
+	Short:   "Sync the page access users of a page with the email addresses of a CSV file.",
+	Long: `Sync the page access users of a page with the email addresses of a CSV file. The addresses
+are read from the email column when the first row names the columns, or else from the first column
+of every row.
+
+Users are created for the addresses without one, and added to the page access group given with
+--group. With --prune, the users whose address is not in the file are deleted. When --group is set,
+only its members are pruned: they are removed from the group, and only deleted when it was their
+only group. --dry-run reports the changes without making them.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		data, err := utils.ReadFile(pageAccessUserCSV)
+		exitOnError(err)
+		emails, err := readEmails(data)
+		exitOnError(err)
+
+		groupID, err := lookup(c).pageAccessGroupID(pageID, pageAccessUserSyncGroup)
+		exitOnError(err)
+
+		users, err := c.ListPageAccessUsers(pageID, &client.ListOptions{All: true})
+		exitOnError(err)
+		existing := map[string]client.PageAccessUser{}
+		for _, user := range users {
+			existing[strings.ToLower(user.Email)] = user
+		}
+
+		action := func(verb, email string, do func() error) bool {
+			if pageAccessUserDryRun {
+				fmt.Printf("%s %s (dry run)\n", verb, email)
+				return true
+			}
+			if err := do(); err != nil {
+				fmt.Fprintf(os.Stderr, "%s: %v\n", email, err)
+				return false
+			}
+			fmt.Printf("%s %s\n", verb, email)
+			return true
+		}
+
+		listed := map[string]bool{}
+		created, updated, deleted, unchanged, failed := 0, 0, 0, 0, 0
+		for _, email := range emails {
+			listed[email] = true
+			user, ok := existing[email]
+			switch {
+			case !ok:
+				params := client.PageAccessUserParams{Email: &email}
+				if groupID != "" {
+					params.PageAccessGroupIDs = &[]string{groupID}
+				}
+				if action("created", email, func() error {
+					_, err := c.CreatePageAccessUser(pageID, params)
+					return err
+				}) {
+					created++
+				} else {
+					failed++
+				}
+			case groupID != "" && !utils.Contains(user.PageAccessGroupIDs, groupID):
+				groups := utils.AppendMissing(user.PageAccessGroupIDs, groupID)
+				if action("updated", email, func() error {
+					_, err := c.UpdatePageAccessUser(pageID, user.ID, client.PageAccessUserParams{PageAccessGroupIDs: &groups})
+					return err
+				}) {
+					updated++
+				} else {
+					failed++
+				}
+			default:
+				unchanged++
+			}
+		}
+
+		if pageAccessUserPrune {
+			for _, user := range users {
+				email := strings.ToLower(user.Email)
+				if listed[email] || (groupID != "" && !utils.Contains(user.PageAccessGroupIDs, groupID)) {
+					continue
+				}
+
+				// Pruning a group only takes its members out of it, users are only deleted
+				// with their last group.
+				if groups := utils.Remove(user.PageAccessGroupIDs, groupID); groupID != "" && len(groups) > 0 {
+					if action("removed from group", email, func() error {
+						_, err := c.UpdatePageAccessUser(pageID, user.ID, client.PageAccessUserParams{PageAccessGroupIDs: &groups})
+						return err
+					}) {
+						updated++
+					} else {
+						failed++
+					}
+					continue
+				}
+				if action("deleted", email, func() error {
+					return c.DeletePageAccessUser(pageID, user.ID)
+				}) {
+					deleted++
+				} else {
+					failed++
+				}
+			}
+		}
+
+		fmt.Fprintf(os.Stderr, "%d created, %d updated, %d deleted, %d unchanged, %d failed\n", created, updated, deleted, unchanged, failed)
+		if failed > 0 {
+			os.Exit(exitError)
+		}
+	},
+}
+
+// readEmails returns the distinct email addresses of a CSV file in lower case, read from its
+// email column or else from its first column.
+func readEmails(data []byte) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, err
+	}
+
+	column := 0
+	if len(records) > 0 {
+		for i, name := range records[0] {
+			if strings.EqualFold(strings.TrimSpace(name), "email") {
+				column = i
+				records = records[1:]
+				break
+			}
+		}
+	}
+
+	emails := []string{}
+	seen := map[string]bool{}
+	for i, record := range records {
+		if column >= len(record) {
+			continue
+		}
+		email := strings.ToLower(strings.TrimSpace(record[column]))
+		if email == "" || seen[email] {
+			continue
+		}
+		if !strings.Contains(email, "@") {
+			return nil, fmt.Errorf("row %d: %q is not an email address", i+1, email)
+		}
+		seen[email] = true
+		emails = append(emails, email)
+	}
+	return emails, nil
+}
+
+func init() {
+	importPageAccessUsersCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	importPageAccessUsersCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	importPageAccessUsersCmd.Flags().StringVar(&pageAccessUserCSV, "csv", "", "CSV file to read the email addresses from, - to read them from stdin (required)")
+	importPageAccessUsersCmd.Flags().StringVarP(&pageAccessUserSyncGroup, "group", "g", "", "Identifier or name of the page access group to add the users to")
+	importPageAccessUsersCmd.Flags().BoolVar(&pageAccessUserPrune, "prune", false, "Delete the page access users that are not in the file, or remove them from --group when it is set")
+	importPageAccessUsersCmd.Flags().BoolVar(&pageAccessUserDryRun, "dry-run", false, "Report the changes without making them")
+	importPageAccessUsersCmd.MarkFlagRequired("page-id")
+	importPageAccessUsersCmd.MarkFlagRequired("csv")
+	importCmd.AddCommand(importPageAccessUsersCmd)
+}
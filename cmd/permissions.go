@@ -0,0 +1,122 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"errors"
+	"github.com/spf13/cobra"
+)
+
+var permissionsUser string
+var permissionsPageConfiguration bool
+var permissionsIncidentManager bool
+var permissionsMaintenanceManager bool
+var permissionsRevoke bool
+
+var getPermissionsCmd = &cobra.Command{
+	Use:     "permissions",
+	Aliases: []string{"permission"},
+	Short:   "Get the roles of a user on the pages of an organization.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		permissionsUser, err = lookup(c).userID(organizationID, permissionsUser)
+		exitOnError(err)
+
+		permissions, err := c.GetPermissions(organizationID, permissionsUser)
+		exitOnError(err)
+		printOutput(permissions)
+	},
+}
+
+var updatePermissionsCmd = &cobra.Command{
+	Use:     "permissions",
+	Aliases: []string{"permission"},
+	Short:   "Change the roles of a user on a page, keeping their roles on other pages.",
+	Long: `Change the roles of a user on a page, keeping their roles on other pages. Only the roles set
+with --page-configuration, --incident-manager and --maintenance-manager are changed, and a user
+without access to the page is given access with these roles. --revoke removes the access of the
+user to the page, and cannot be used with the role flags.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if permissionsRevoke {
+			for _, role := range []string{"page-configuration", "incident-manager", "maintenance-manager"} {
+				if cmd.Flags().Changed(role) {
+					exitOnError(errors.New("--revoke removes every role of the user on the page, it cannot be used with --" + role))
+				}
+			}
+		}
+
+		c := newClient()
+
+		var err error
+		permissionsUser, err = lookup(c).userID(organizationID, permissionsUser)
+		exitOnError(err)
+
+		// Permissions are replaced as a whole, so the roles on the other pages are sent back unchanged.
+		current, err := c.GetPermissions(organizationID, permissionsUser)
+		exitOnError(err)
+
+		pages := []client.PagePermissions{}
+		page := client.PagePermissions{PageID: pageID}
+		for _, p := range current.Pages {
+			if p.PageID == pageID {
+				page = p
+				continue
+			}
+			pages = append(pages, p)
+		}
+
+		if !permissionsRevoke {
+			if cmd.Flags().Changed("page-configuration") {
+				page.PageConfiguration = permissionsPageConfiguration
+			}
+			if cmd.Flags().Changed("incident-manager") {
+				page.IncidentManager = permissionsIncidentManager
+			}
+			if cmd.Flags().Changed("maintenance-manager") {
+				page.MaintenanceManager = permissionsMaintenanceManager
+			}
+			pages = append(pages, page)
+		}
+
+		permissions, err := c.SetPermissions(organizationID, permissionsUser, pages)
+		exitOnError(err)
+		printOutput(permissions)
+	},
+}
+
+func init() {
+	getPermissionsCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getPermissionsCmd.Flags().StringVar(&organizationID, "organization-id", "", "Organization identifier (required)")
+	getPermissionsCmd.Flags().StringVarP(&permissionsUser, "user", "u", "", "User identifier or email address (required)")
+	getPermissionsCmd.MarkFlagRequired("organization-id")
+	getPermissionsCmd.MarkFlagRequired("user")
+	updatePermissionsCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updatePermissionsCmd.Flags().StringVar(&organizationID, "organization-id", "", "Organization identifier (required)")
+	updatePermissionsCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updatePermissionsCmd.Flags().StringVarP(&permissionsUser, "user", "u", "", "User identifier or email address (required)")
+	updatePermissionsCmd.Flags().BoolVar(&permissionsPageConfiguration, "page-configuration", false, "Whether the user can change the configuration of the page")
+	updatePermissionsCmd.Flags().BoolVar(&permissionsIncidentManager, "incident-manager", false, "Whether the user can manage the incidents of the page")
+	updatePermissionsCmd.Flags().BoolVar(&permissionsMaintenanceManager, "maintenance-manager", false, "Whether the user can manage the maintenances of the page")
+	updatePermissionsCmd.Flags().BoolVar(&permissionsRevoke, "revoke", false, "Remove the access of the user to the page")
+	updatePermissionsCmd.MarkFlagRequired("organization-id")
+	updatePermissionsCmd.MarkFlagRequired("page-id")
+	updatePermissionsCmd.MarkFlagRequired("user")
+	getCmd.AddCommand(getPermissionsCmd)
+	updateCmd.AddCommand(updatePermissionsCmd)
+}
@@ -0,0 +1,177 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../playbook"
+	"../utils"
+	"fmt"
+	homedir "github.com/mitchellh/go-homedir"
+	"github.com/spf13/cobra"
+	"os"
+	"path/filepath"
+)
+
+var playbookDir string
+var playbookVars map[string]string
+
+var incidentCmd = &cobra.Command{
+	Use:   "incident",
+	Short: "Runs incidents from local playbooks",
+	Long: `Runs incidents from local playbooks. A playbook is a YAML file in $HOME/.statuspage/playbooks
+describing an incident from its first message to its resolution, for example db-failover.yaml:
+
+  name: Database failover in {{.cluster}}
+  status: investigating
+  body: We are investigating errors from the {{.cluster}} database.
+  components:
+    Database {{.cluster}}: major_outage
+  updates:
+    - status: identified
+      body: The {{.cluster}} database is failing over to its replica.
+    - status: monitoring
+      body: The failover is complete, we are monitoring the database.
+      components:
+        Database {{.cluster}}: degraded_performance
+    - status: resolved
+      body: The {{.cluster}} database is running normally.
+      components:
+        Database {{.cluster}}: operational
+
+The name, bodies and component names are Go templates filled in with --var.`,
+}
+
+var runPlaybookCmd = &cobra.Command{
+	Use:   "run-playbook <playbook>",
+	Short: "Create an incident from a playbook.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		p, err := playbook.Load(playbookDirectory(), args[0])
+		exitOnError(err)
+
+		// Every stage is filled in now, so a missing variable is not found halfway through the incident.
+		name, err := utils.RenderTemplate("name", p.Name, playbookVars)
+		exitOnError(err)
+		stage, err := p.Stage.Render(playbookVars)
+		exitOnError(err)
+		for _, update := range p.Updates {
+			_, err := update.Render(playbookVars)
+			exitOnError(err)
+		}
+
+		components, err := lookup(c).componentIDs(pageID, stage.Components)
+		exitOnError(err)
+
+		params := incidentParams(&stage.Status, &stage.Body, components)
+		params.Name = &name
+		params.DeliverNotifications = p.DeliverNotifications
+		incident, err := createIncident(c, pageID, params)
+		exitOnError(err)
+
+		if len(p.Updates) > 0 {
+			runs, err := playbook.LoadRuns(playbookRunsFile())
+			exitOnError(err)
+			runs[incident.ID] = &playbook.Run{PageID: pageID, Playbook: args[0], Vars: playbookVars}
+			exitOnError(runs.Save(playbookRunsFile()))
+		}
+		printOutput(incident)
+	},
+}
+
+var advanceIncidentCmd = &cobra.Command{
+	Use:   "advance <incident>",
+	Short: "Post the next update of the playbook an incident was created from.",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		runs, err := playbook.LoadRuns(playbookRunsFile())
+		exitOnError(err)
+
+		id := args[0]
+		run, ok := runs[id]
+		if !ok && pageID != "" {
+			id, err = lookup(c).incidentID(pageID, id)
+			exitOnError(err)
+			run, ok = runs[id]
+		}
+		if !ok {
+			exitOnError(fmt.Errorf("incident %s was not created with run-playbook, or every update of its playbook was posted", args[0]))
+		}
+
+		p, err := playbook.Load(playbookDirectory(), run.Playbook)
+		exitOnError(err)
+		if run.Posted >= len(p.Updates) {
+			exitOnError(fmt.Errorf("every update of playbook %s was posted to incident %s", run.Playbook, id))
+		}
+
+		stage, err := p.Updates[run.Posted].Render(run.Vars)
+		exitOnError(err)
+
+		components, err := lookup(c).componentIDs(run.PageID, stage.Components)
+		exitOnError(err)
+
+		params := incidentParams(&stage.Status, &stage.Body, components)
+		params.DeliverNotifications = p.DeliverNotifications
+		incident, err := updateIncident(c, run.PageID, id, params)
+		exitOnError(err)
+
+		run.Posted++
+		if run.Posted == len(p.Updates) {
+			delete(runs, id)
+		}
+		exitOnError(runs.Save(playbookRunsFile()))
+
+		fmt.Fprintf(os.Stderr, "update %d of %d of playbook %s posted\n", run.Posted, len(p.Updates), run.Playbook)
+		printOutput(incident)
+	},
+}
+
+// playbookDirectory returns the directory set with --playbook-dir, or else $HOME/.statuspage/playbooks.
+func playbookDirectory() string {
+	if playbookDir != "" {
+		return playbookDir
+	}
+	return filepath.Join(statuspageDir(), "playbooks")
+}
+
+// playbookRunsFile returns the file recording the incidents created from playbooks and the updates
+// posted to them.
+func playbookRunsFile() string {
+	return filepath.Join(statuspageDir(), "playbook-runs.yaml")
+}
+
+func statuspageDir() string {
+	home, err := homedir.Dir()
+	exitOnError(err)
+	return filepath.Join(home, ".statuspage")
+}
+
+func init() {
+	runPlaybookCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	runPlaybookCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	runPlaybookCmd.Flags().StringToStringVar(&playbookVars, "var", map[string]string{}, "Variables filled in the playbook, as key=value")
+	runPlaybookCmd.Flags().StringVar(&playbookDir, "playbook-dir", "", "Directory of the playbooks (default is $HOME/.statuspage/playbooks)")
+	runPlaybookCmd.MarkFlagRequired("page-id")
+	advanceIncidentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	advanceIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name, to refer to the incident by name")
+	advanceIncidentCmd.Flags().StringVar(&playbookDir, "playbook-dir", "", "Directory of the playbooks (default is $HOME/.statuspage/playbooks)")
+	incidentCmd.AddCommand(runPlaybookCmd)
+	incidentCmd.AddCommand(advanceIncidentCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
@@ -0,0 +1,132 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"github.com/spf13/cobra"
+)
+
+var postmortemFile string
+var postmortemNotifySubscribers bool
+var postmortemNotifyTwitter bool
+var postmortemCustomTweet string
+
+var getPostmortemCmd = &cobra.Command{
+	Use:   "postmortem",
+	Short: "Get the postmortem of an incident.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		postmortem, err := c.GetPostmortem(pageID, incidentID)
+		exitOnError(err)
+		printOutput(postmortem)
+	},
+}
+
+var updatePostmortemCmd = &cobra.Command{
+	Use:   "postmortem",
+	Short: "Replace the draft of the postmortem of an incident with the content of a file.",
+	Long: `Replace the draft of the postmortem of an incident with the content of a Markdown file, or of
+stdin with --file -. The draft is only shown on the page once it is published with
+'statuspage publish postmortem'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		body, err := utils.ReadFile(postmortemFile)
+		exitOnError(err)
+
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		postmortem, err := c.UpdatePostmortem(pageID, incidentID, string(body))
+		exitOnError(err)
+		printOutput(postmortem)
+	},
+}
+
+var publishPostmortemCmd = &cobra.Command{
+	Use:   "postmortem",
+	Short: "Publish the draft of the postmortem of an incident.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		postmortem, err := c.PublishPostmortem(pageID, incidentID, client.PublishPostmortemParams{
+			NotifySubscribers: changedBool(cmd, "notify-subscribers", postmortemNotifySubscribers),
+			NotifyTwitter:     changedBool(cmd, "notify-twitter", postmortemNotifyTwitter),
+			CustomTweet:       changedString(cmd, "custom-tweet", postmortemCustomTweet),
+		})
+		exitOnError(err)
+		printOutput(postmortem)
+	},
+}
+
+var revertPostmortemCmd = &cobra.Command{
+	Use:   "postmortem",
+	Short: "Unpublish the postmortem of an incident, turning it back into a draft.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		incidentID, err = lookup(c).incidentID(pageID, incidentID)
+		exitOnError(err)
+
+		postmortem, err := c.RevertPostmortem(pageID, incidentID)
+		exitOnError(err)
+		printOutput(postmortem)
+	},
+}
+
+func init() {
+	getPostmortemCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getPostmortemCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getPostmortemCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	getPostmortemCmd.MarkFlagRequired("page-id")
+	getPostmortemCmd.MarkFlagRequired("incident")
+	updatePostmortemCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	updatePostmortemCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	updatePostmortemCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	updatePostmortemCmd.Flags().StringVarP(&postmortemFile, "file", "f", "", "Markdown file holding the postmortem, - to read it from stdin (required)")
+	updatePostmortemCmd.MarkFlagRequired("page-id")
+	updatePostmortemCmd.MarkFlagRequired("incident")
+	updatePostmortemCmd.MarkFlagRequired("file")
+	publishPostmortemCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	publishPostmortemCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	publishPostmortemCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	publishPostmortemCmd.Flags().BoolVar(&postmortemNotifySubscribers, "notify-subscribers", false, "Notify the subscribers of the incident")
+	publishPostmortemCmd.Flags().BoolVar(&postmortemNotifyTwitter, "notify-twitter", false, "Tweet the postmortem")
+	publishPostmortemCmd.Flags().StringVar(&postmortemCustomTweet, "custom-tweet", "", "Text of the tweet, when it is sent with --notify-twitter")
+	publishPostmortemCmd.MarkFlagRequired("page-id")
+	publishPostmortemCmd.MarkFlagRequired("incident")
+	revertPostmortemCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	revertPostmortemCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	revertPostmortemCmd.Flags().StringVar(&incidentID, "incident", "", "Incident identifier or name (required)")
+	revertPostmortemCmd.MarkFlagRequired("page-id")
+	revertPostmortemCmd.MarkFlagRequired("incident")
+	getCmd.AddCommand(getPostmortemCmd)
+	updateCmd.AddCommand(updatePostmortemCmd)
+	publishCmd.AddCommand(publishPostmortemCmd)
+	revertCmd.AddCommand(revertPostmortemCmd)
+}
@@ -0,0 +1,139 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"github.com/mattn/go-isatty"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// stdin is shared by the prompts, as a buffered reader may read ahead of the line it returns.
+var stdin = bufio.NewReader(os.Stdin)
+
+// terminal reports whether the command is run by someone who can answer prompts.
+func terminal() bool {
+	return isatty.IsTerminal(os.Stdin.Fd()) && isatty.IsTerminal(os.Stderr.Fd())
+}
+
+// readLine prints label on stderr and returns the line typed in reply, or def when it is empty.
+func readLine(label, def string) (string, error) {
+	if def != "" {
+		fmt.Fprintf(os.Stderr, "%s [%s]: ", label, def)
+	} else {
+		fmt.Fprintf(os.Stderr, "%s: ", label)
+	}
+
+	line, err := stdin.ReadString('\n')
+	if err == io.EOF && line == "" {
+		return "", errors.New("no answer to " + strings.ToLower(label))
+	}
+	if err != nil && err != io.EOF {
+		return "", err
+	}
+	if line = strings.TrimSpace(line); line == "" {
+		return def, nil
+	}
+	return line, nil
+}
+
+// promptString asks for a value until one is given, or def is accepted.
+func promptString(label, def string) (string, error) {
+	for {
+		value, err := readLine(label, def)
+		if err != nil || value != "" {
+			return value, err
+		}
+	}
+}
+
+// promptSelect asks to choose one of choices by number or value, def being the default.
+func promptSelect(label string, choices []string, def string) (string, error) {
+	for i, choice := range choices {
+		fmt.Fprintf(os.Stderr, "  %d) %s\n", i+1, choice)
+	}
+	for {
+		answer, err := promptString(label, def)
+		if err != nil {
+			return "", err
+		}
+		if i, err := strconv.Atoi(answer); err == nil && i >= 1 && i <= len(choices) {
+			return choices[i-1], nil
+		}
+		for _, choice := range choices {
+			if strings.EqualFold(choice, answer) {
+				return choice, nil
+			}
+		}
+		fmt.Fprintf(os.Stderr, "%q is not one of the choices, answer with a number from 1 to %d\n", answer, len(choices))
+	}
+}
+
+// promptMultiSelect asks to choose any of choices by number, separated by commas or spaces, and
+// returns the indexes chosen. selected are the indexes chosen by default, and none or - chooses
+// nothing.
+func promptMultiSelect(label string, choices []string, selected []int) ([]int, error) {
+	def := []string{}
+	for _, i := range selected {
+		def = append(def, strconv.Itoa(i+1))
+	}
+	for i, choice := range choices {
+		fmt.Fprintf(os.Stderr, "  %d) %s\n", i+1, choice)
+	}
+
+	for {
+		answer, err := readLine(label, strings.Join(def, ","))
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(answer, "none") || answer == "-" {
+			return []int{}, nil
+		}
+
+		chosen := []int{}
+		seen := map[int]bool{}
+		for _, field := range strings.FieldsFunc(answer, func(r rune) bool { return r == ',' || r == ' ' }) {
+			i, err := strconv.Atoi(field)
+			if err != nil || i < 1 || i > len(choices) {
+				chosen = nil
+				fmt.Fprintf(os.Stderr, "%q is not one of the choices, answer with numbers from 1 to %d\n", field, len(choices))
+				break
+			}
+			if !seen[i-1] {
+				seen[i-1] = true
+				chosen = append(chosen, i-1)
+			}
+		}
+		if chosen != nil {
+			return chosen, nil
+		}
+	}
+}
+
+// promptConfirm asks a yes or no question, no being the default.
+func promptConfirm(label string) (bool, error) {
+	answer, err := readLine(label+" [y/N]", "")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,126 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+)
+
+var resolveBody string
+var resolveComponentStatus string
+var resolveDeliverNotifications bool
+var resolvePrintPostmortemHint bool
+
+var resolveCmd = &cobra.Command{
+	Use:   "resolve",
+	Short: "Resolves incidents in statuspage",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("statuspage resolve error: missing required argument. See 'statuspage resolve -h' for help.")
+	},
+}
+
+var resolveIncidentCmd = &cobra.Command{
+	Use:   "incident <incident>",
+	Short: "Resolve an incident and set the components it affects back to operational.",
+	Long: `Resolve an incident, or complete a scheduled maintenance, with a resolution message. Every
+component the incident affects is set back to operational, or to the status given with
+--component-status, and the status changes are reported on stderr.`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		if (utils.Contains(client.ComponentStatuses, resolveComponentStatus)) != true {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		c := newClient()
+
+		id, err := lookup(c).incidentID(pageID, args[0])
+		exitOnError(err)
+
+		incident, err := c.GetIncident(pageID, id)
+		exitOnError(err)
+
+		status := "resolved"
+		if incident.ScheduledFor != nil {
+			status = "completed"
+		}
+
+		affected := affectedComponents(incident)
+		components := map[string]string{}
+		for _, component := range affected {
+			components[component.ID] = resolveComponentStatus
+		}
+
+		params := incidentParams(&status, &resolveBody, components)
+		params.DeliverNotifications = changedBool(cmd, "deliver-notifications", resolveDeliverNotifications)
+		resolved, err := updateIncident(c, pageID, id, params)
+		exitOnError(err)
+
+		fmt.Fprintf(os.Stderr, "incident %q %s: %s -> %s\n", incident.Name, incident.ID, incident.Status, resolved.Status)
+		for _, component := range affected {
+			if component.Status == resolveComponentStatus {
+				fmt.Fprintf(os.Stderr, "component %q %s: already %s\n", component.Name, component.ID, component.Status)
+			} else {
+				fmt.Fprintf(os.Stderr, "component %q %s: %s -> %s\n", component.Name, component.ID, component.Status, resolveComponentStatus)
+			}
+		}
+		if resolvePrintPostmortemHint {
+			// The API has no way to set postmortem reminders, so a hint is printed instead.
+			fmt.Fprintf(os.Stderr, "Hint: write the postmortem of incident %q %s and upload it with 'statuspage update postmortem --incident %s -f <FILE>'\n", incident.Name, incident.Shortlink, incident.ID)
+		}
+
+		printOutput(resolved)
+	},
+}
+
+// affectedComponents returns the components of an incident with their current status, together
+// with the components named in its updates with the last status they were set to.
+func affectedComponents(incident *client.Incident) []client.Component {
+	seen := map[string]bool{}
+	components := []client.Component{}
+	for _, component := range incident.Components {
+		seen[component.ID] = true
+		components = append(components, component)
+	}
+
+	// Updates are listed most recent first.
+	for _, update := range incident.IncidentUpdates {
+		for _, affected := range update.AffectedComponents {
+			if affected.Code == "" || seen[affected.Code] {
+				continue
+			}
+			seen[affected.Code] = true
+			components = append(components, client.Component{ID: affected.Code, Name: affected.Name, Status: affected.NewStatus})
+		}
+	}
+	return components
+}
+
+func init() {
+	resolveIncidentCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	resolveIncidentCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	resolveIncidentCmd.Flags().StringVarP(&resolveBody, "body", "b", "This incident has been resolved.", "The resolution message, created as a new incident update")
+	resolveIncidentCmd.Flags().StringVarP(&resolveComponentStatus, "component-status", "s", "operational", "Status the affected components are set to. Valid choices are: operational, under_maintenance, degraded_performance, partial_outage, major_outage")
+	resolveIncidentCmd.Flags().BoolVar(&resolveDeliverNotifications, "deliver-notifications", true, "Notify subscribers of the resolution")
+	resolveIncidentCmd.Flags().BoolVar(&resolvePrintPostmortemHint, "print-postmortem-hint", false, "Print a hint on stderr to write and upload the postmortem of the incident")
+	resolveIncidentCmd.MarkFlagRequired("page-id")
+	resolveCmd.AddCommand(resolveIncidentCmd)
+	rootCmd.AddCommand(resolveCmd)
+}
@@ -16,20 +16,38 @@ limitations under the License.
 package cmd
 
 import (
+	"../client"
+	"../config"
+	"../utils"
 	"fmt"
 	homedir "github.com/mitchellh/go-homedir"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"os"
+	"path/filepath"
+	"time"
 )
 
 var apiKey string
 var pageID string
+var organizationID string
 var cfgFile string
+var profileFlag string
+var timeout time.Duration
+var maxRetries int
+
+// cfg is the content of the configuration file and profile the profile selected from it.
+var cfg *config.Config
+var profile = &config.Profile{}
 
 var rootCmd = &cobra.Command{
 	Use:   "statuspage",
 	Short: "A command line interface for Atlassian statuspage.",
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyProfile(cmd); err != nil {
+			return err
+		}
+		return validateOutput()
+	},
 }
 
 func Execute() {
@@ -42,25 +60,65 @@ func Execute() {
 func init() {
 	cobra.OnInitialize(initConfig)
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.statuspage.yaml)")
+	rootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "STATUSPAGE_PROFILE environment variable. Profile of the config file to use (default is the current profile)")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "json", "Output format. One of: json, yaml, table, name, jsonpath=..., go-template=...")
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", client.DefaultTimeout, "Time allowed for each attempt of an API request")
+	rootCmd.PersistentFlags().IntVar(&maxRetries, "max-retries", client.DefaultMaxRetries, "Number of times a rate limited or failed API request is retried")
 	rootCmd.Flags().BoolP("toggle", "t", false, "Help message for toggle")
 }
 
 func initConfig() {
-	if cfgFile != "" {
-		viper.SetConfigFile(cfgFile)
-	} else {
+	if cfgFile == "" {
 		home, err := homedir.Dir()
 		if err != nil {
 			fmt.Println(err)
 			os.Exit(1)
 		}
 
-		viper.AddConfigPath(home)
-		viper.SetConfigName(".statuspage")
+		cfgFile = filepath.Join(home, ".statuspage.yaml")
+	}
+
+	var err error
+	cfg, err = config.Load(cfgFile)
+	if err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// profileName returns the profile selected with --profile or STATUSPAGE_PROFILE, or the current
+// profile of the configuration file.
+func profileName() string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	if name := utils.GetEnv("STATUSPAGE_PROFILE"); name != "" {
+		return name
+	}
+	return cfg.CurrentProfile
+}
+
+// applyProfile loads the selected profile and uses its settings for the flags that were not set
+// on the command line.
+func applyProfile(cmd *cobra.Command) error {
+	var err error
+	profile, err = cfg.Profile(profileName())
+	if err != nil {
+		return err
+	}
+
+	if !cmd.Flags().Changed("output") && profile.Output != "" {
+		output = profile.Output
 	}
 
-	viper.AutomaticEnv()
-	if err := viper.ReadInConfig(); err == nil {
-		fmt.Println("Using config file:", viper.ConfigFileUsed())
+	// Setting the flags rather than the variables also satisfies the required flag checks.
+	defaults := map[string]string{"page-id": profile.PageID, "organization-id": profile.OrganizationID}
+	for name, value := range defaults {
+		if flag := cmd.Flags().Lookup(name); flag != nil && !flag.Changed && value != "" {
+			if err := cmd.Flags().Set(name, value); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
 }
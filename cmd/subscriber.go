@@ -0,0 +1,193 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"fmt"
+	"github.com/spf13/cobra"
+	"net/url"
+	"os"
+)
+
+var subscriberID string
+var subscriberType string
+var subscriberState string
+var subscriberEmail string
+var subscriberEndpoint string
+var subscriberPhoneCountry string
+var subscriberPhoneNumber string
+var subscriberComponents []string
+var subscriberSkipConfirmation bool
+var subscriberSkipUnsubscription bool
+
+var getSubscriberCmd = &cobra.Command{
+	Use:     "subscriber",
+	Aliases: []string{"subscribers"},
+	Short:   "Get a list of subscribers or a subscriber with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if subscriberID == "" {
+			if !validSubscriberFilters() {
+				cmd.Help()
+				os.Exit(1)
+			}
+
+			printList(func(fn func([]client.Subscriber) error) error {
+				return c.EachSubscribers(pageID, subscriberListOptions(), fn)
+			})
+			return
+		}
+
+		subscriber, err := c.GetSubscriber(pageID, subscriberID)
+		exitOnError(err)
+		printOutput(subscriber)
+	},
+}
+
+var createSubscriberCmd = &cobra.Command{
+	Use:   "subscriber",
+	Short: "Subscribe an email address, phone number or webhook to a page.",
+	Long: `Subscribe an email address, phone number or webhook to a page. Webhook subscribers also need an
+email address, which is told when the webhook fails. Subscribers are notified of every component
+unless --components is set.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		components, err := lookup(c).componentIDList(pageID, subscriberComponents)
+		exitOnError(err)
+
+		subscriber, err := c.CreateSubscriber(pageID, client.SubscriberParams{
+			Email:                        changedString(cmd, "email", subscriberEmail),
+			Endpoint:                     changedString(cmd, "endpoint", subscriberEndpoint),
+			PhoneCountry:                 changedString(cmd, "phone-country", subscriberPhoneCountry),
+			PhoneNumber:                  changedString(cmd, "phone-number", subscriberPhoneNumber),
+			SkipConfirmationNotification: changedBool(cmd, "skip-confirmation-notification", subscriberSkipConfirmation),
+			ComponentIDs:                 components,
+		})
+		exitOnError(err)
+		printOutput(subscriber)
+	},
+}
+
+var deleteSubscriberCmd = &cobra.Command{
+	Use:   "subscriber",
+	Short: "Unsubscribe a subscriber with a specified identifier.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		subscriber, err := c.DeleteSubscriber(pageID, subscriberID, subscriberSkipUnsubscription)
+		exitOnError(err)
+		printOutput(subscriber)
+	},
+}
+
+var resendConfirmationCmd = &cobra.Command{
+	Use:   "resend-confirmation",
+	Short: "Resends confirmations to subscribers in statuspage",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println("statuspage resend-confirmation error: missing required argument. See 'statuspage resend-confirmation -h' for help.")
+	},
+}
+
+var resendSubscriberConfirmationCmd = &cobra.Command{
+	Use:   "subscriber",
+	Short: "Send the confirmation of an unconfirmed subscriber again.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		exitOnError(c.ResendConfirmation(pageID, subscriberID))
+		fmt.Println("confirmation of subscriber " + subscriberID + " sent")
+	},
+}
+
+// subscriberContact returns the email address, phone number, webhook or Slack channel a subscriber
+// is notified on.
+func subscriberContact(s client.Subscriber) string {
+	switch {
+	case s.Endpoint != "":
+		return s.Endpoint
+	case s.Email != "":
+		return s.Email
+	case s.DisplayPhoneNumber != "":
+		return s.DisplayPhoneNumber
+	case s.PhoneNumber != "":
+		return s.PhoneNumber
+	}
+	return s.ObfuscatedChannelName
+}
+
+// validSubscriberFilters reports whether the --type and --state filters are valid.
+func validSubscriberFilters() bool {
+	return (subscriberType == "" || utils.Contains(client.SubscriberTypes, subscriberType)) &&
+		(subscriberState == "" || utils.Contains(client.SubscriberStates, subscriberState))
+}
+
+// subscriberListOptions returns the list options set with the pagination flags and the --type and
+// --state filters.
+func subscriberListOptions() *client.ListOptions {
+	opts := listOptions()
+	opts.Query = url.Values{}
+	if subscriberType != "" {
+		opts.Query.Set("type", subscriberType)
+	}
+	if subscriberState != "" {
+		opts.Query.Set("state", subscriberState)
+	}
+	return opts
+}
+
+// addSubscriberFilterFlags adds the --type and --state flags filtering the subscribers listed.
+func addSubscriberFilterFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&subscriberType, "type", "t", "", "Only list subscribers of this type. Valid choices are: email, sms, webhook, slack, integration_partner")
+	cmd.Flags().StringVar(&subscriberState, "state", "", "Only list subscribers in this state. Valid choices are: active, unconfirmed, quarantined, all (default is active)")
+}
+
+func init() {
+	getSubscriberCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getSubscriberCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	getSubscriberCmd.Flags().StringVarP(&subscriberID, "id", "i", "", "Subscriber identifier")
+	getSubscriberCmd.MarkFlagRequired("page-id")
+	addSubscriberFilterFlags(getSubscriberCmd)
+	addListFlags(getSubscriberCmd)
+	createSubscriberCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createSubscriberCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	createSubscriberCmd.Flags().StringVarP(&subscriberEmail, "email", "e", "", "Email address to notify")
+	createSubscriberCmd.Flags().StringVar(&subscriberEndpoint, "endpoint", "", "Webhook URL to notify, together with --email")
+	createSubscriberCmd.Flags().StringVar(&subscriberPhoneCountry, "phone-country", "", "Two letter code of the country of the phone number, such as GB or US")
+	createSubscriberCmd.Flags().StringVar(&subscriberPhoneNumber, "phone-number", "", "Phone number to notify by SMS, together with --phone-country")
+	createSubscriberCmd.Flags().StringSliceVarP(&subscriberComponents, "components", "c", []string{}, "Identifiers or names of the components to notify the subscriber of")
+	createSubscriberCmd.Flags().BoolVar(&subscriberSkipConfirmation, "skip-confirmation-notification", false, "Subscribe without asking the subscriber to confirm")
+	createSubscriberCmd.MarkFlagRequired("page-id")
+	deleteSubscriberCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deleteSubscriberCmd.Flags().StringVarP(&subscriberID, "id", "i", "", "Subscriber identifier (required)")
+	deleteSubscriberCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	deleteSubscriberCmd.Flags().BoolVar(&subscriberSkipUnsubscription, "skip-unsubscription-notification", false, "Unsubscribe without telling the subscriber")
+	deleteSubscriberCmd.MarkFlagRequired("id")
+	deleteSubscriberCmd.MarkFlagRequired("page-id")
+	resendSubscriberConfirmationCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	resendSubscriberConfirmationCmd.Flags().StringVarP(&subscriberID, "id", "i", "", "Subscriber identifier (required)")
+	resendSubscriberConfirmationCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	resendSubscriberConfirmationCmd.MarkFlagRequired("id")
+	resendSubscriberConfirmationCmd.MarkFlagRequired("page-id")
+	getCmd.AddCommand(getSubscriberCmd)
+	createCmd.AddCommand(createSubscriberCmd)
+	deleteCmd.AddCommand(deleteSubscriberCmd)
+	resendConfirmationCmd.AddCommand(resendSubscriberConfirmationCmd)
+	rootCmd.AddCommand(resendConfirmationCmd)
+}
@@ -0,0 +1,211 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"../utils"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"github.com/spf13/cobra"
+	"os"
+	"strings"
+	"time"
+)
+
+var subscriberExportCSV string
+var subscriberImportCSV string
+var subscriberProgressEvery int
+
+// subscriberColumns are the columns of exported subscriber CSV files. Imports only read the
+// columns describing how to notify a subscriber and ignore the others.
+var subscriberColumns = []string{"id", "type", "email", "phone_country", "phone_number", "endpoint", "components", "created_at"}
+
+var exportSubscribersCmd = &cobra.Command{
+	Use:     "subscribers",
+	Aliases: []string{"subscriber"},
+	Short:   "Export the subscribers of a page as CSV.",
+	Long: `Export the subscribers of a page as CSV, with the columns id, type, email, phone_country,
+phone_number, endpoint, components and created_at. Components are listed by name, separated by
+semicolons. The file can be imported with 'statuspage import subscribers'.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if !validSubscriberFilters() {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		c := newClient()
+
+		components, err := c.ListComponents(pageID, &client.ListOptions{All: true})
+		exitOnError(err)
+		names := map[string]string{}
+		for _, component := range components {
+			names[component.ID] = component.Name
+		}
+
+		out := os.Stdout
+		if subscriberExportCSV != "-" {
+			out, err = os.Create(subscriberExportCSV)
+			exitOnError(err)
+		}
+
+		w := csv.NewWriter(out)
+		w.Write(subscriberColumns)
+
+		opts := subscriberListOptions()
+		opts.All = true
+		exported := 0
+		err = c.EachSubscribers(pageID, opts, func(page []client.Subscriber) error {
+			for _, s := range page {
+				componentNames := []string{}
+				for _, id := range s.Components {
+					if name, ok := names[id]; ok {
+						id = name
+					}
+					componentNames = append(componentNames, id)
+				}
+				w.Write([]string{s.ID, s.Mode, s.Email, s.PhoneCountry, s.PhoneNumber, s.Endpoint, strings.Join(componentNames, ";"), s.CreatedAt.Format(time.RFC3339)})
+			}
+			w.Flush()
+			exported += len(page)
+			fmt.Fprintf(os.Stderr, "%d subscribers exported\n", exported)
+			return w.Error()
+		})
+		if closeErr := out.Close(); err == nil {
+			err = closeErr
+		}
+		exitOnError(err)
+	},
+}
+
+var importSubscribersCmd = &cobra.Command{
+	Use:     "subscribers",
+	Aliases: []string{"subscriber"},
+	Short:   "Subscribe the email addresses, phone numbers and webhooks of a CSV file to a page.",
+	Long: `Subscribe the email addresses, phone numbers and webhooks of a CSV file to a page. The first row
+names the columns: email, phone_country, phone_number, endpoint and components, a list of component
+identifiers or names separated by semicolons. Other columns, such as the ones of files written by
+'statuspage export subscribers', are ignored.
+
+The API has no bulk endpoint to create subscribers, so they are created one request at a time, and
+the progress is reported every --progress-every rows. Rows that cannot be imported are reported
+and skipped, and the command exits with 1 once every row was tried.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if subscriberProgressEvery < 1 {
+			cmd.Help()
+			os.Exit(1)
+		}
+
+		c := newClient()
+
+		data, err := utils.ReadFile(subscriberImportCSV)
+		exitOnError(err)
+
+		reader := csv.NewReader(bytes.NewReader(data))
+		reader.FieldsPerRecord = -1
+		records, err := reader.ReadAll()
+		exitOnError(err)
+		if len(records) == 0 {
+			exitOnError(errors.New("the CSV file is empty, its first row must name the columns"))
+		}
+
+		columns := map[string]int{}
+		for i, name := range records[0] {
+			columns[strings.ToLower(strings.TrimSpace(name))] = i
+		}
+		field := func(record []string, name string) string {
+			if i, ok := columns[name]; ok && i < len(record) {
+				return strings.TrimSpace(record[i])
+			}
+			return ""
+		}
+
+		rows := records[1:]
+		created, failed := 0, 0
+		for start := 0; start < len(rows); start += subscriberProgressEvery {
+			end := start + subscriberProgressEvery
+			if end > len(rows) {
+				end = len(rows)
+			}
+
+			for i, record := range rows[start:end] {
+				params := client.SubscriberParams{
+					Email:                        stringOrNil(field(record, "email")),
+					Endpoint:                     stringOrNil(field(record, "endpoint")),
+					PhoneCountry:                 stringOrNil(field(record, "phone_country")),
+					PhoneNumber:                  stringOrNil(field(record, "phone_number")),
+					SkipConfirmationNotification: changedBool(cmd, "skip-confirmation-notification", subscriberSkipConfirmation),
+				}
+
+				var refs []string
+				for _, ref := range strings.Split(field(record, "components"), ";") {
+					if ref = strings.TrimSpace(ref); ref != "" {
+						refs = append(refs, ref)
+					}
+				}
+
+				if params.Email == nil && params.PhoneNumber == nil && params.Endpoint == nil {
+					err = errors.New("no email, phone_number or endpoint to notify")
+				} else {
+					params.ComponentIDs, err = lookup(c).componentIDList(pageID, refs)
+				}
+				if err == nil {
+					_, err = c.CreateSubscriber(pageID, params)
+				}
+				if err != nil {
+					failed++
+					// Rows are numbered as in the file, after the header.
+					fmt.Fprintf(os.Stderr, "row %d: %v\n", start+i+2, err)
+					continue
+				}
+				created++
+			}
+			fmt.Fprintf(os.Stderr, "%d of %d rows processed: %d created, %d failed\n", end, len(rows), created, failed)
+		}
+
+		fmt.Printf("%d subscribers created, %d failed\n", created, failed)
+		if failed > 0 {
+			os.Exit(exitError)
+		}
+	},
+}
+
+// stringOrNil returns nil for an empty string, so empty CSV fields are left out of requests.
+func stringOrNil(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func init() {
+	exportSubscribersCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	exportSubscribersCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	exportSubscribersCmd.Flags().StringVar(&subscriberExportCSV, "csv", "-", "CSV file to write the subscribers to, - to write them to stdout")
+	addSubscriberFilterFlags(exportSubscribersCmd)
+	exportSubscribersCmd.MarkFlagRequired("page-id")
+	importSubscribersCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	importSubscribersCmd.Flags().StringVarP(&pageID, "page-id", "p", "", "Page identifier or name (required)")
+	importSubscribersCmd.Flags().StringVar(&subscriberImportCSV, "csv", "", "CSV file to read the subscribers from, - to read them from stdin (required)")
+	importSubscribersCmd.Flags().IntVar(&subscriberProgressEvery, "progress-every", 50, "Number of rows processed between progress reports")
+	importSubscribersCmd.Flags().BoolVar(&subscriberSkipConfirmation, "skip-confirmation-notification", false, "Subscribe without asking the subscribers to confirm")
+	importSubscribersCmd.MarkFlagRequired("page-id")
+	importSubscribersCmd.MarkFlagRequired("csv")
+	exportCmd.AddCommand(exportSubscribersCmd)
+	importCmd.AddCommand(importSubscribersCmd)
+}
@@ -0,0 +1,101 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package cmd
+
+import (
+	"../client"
+	"github.com/spf13/cobra"
+)
+
+var userID string
+var userEmail string
+var userFirstName string
+var userLastName string
+
+var getUserCmd = &cobra.Command{
+	Use:     "user",
+	Aliases: []string{"users"},
+	Short:   "Get a list of the users of an organization or a user with a specified identifier or email address.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		if userID == "" {
+			printList(func(fn func([]client.User) error) error {
+				return c.EachUsers(organizationID, listOptions(), fn)
+			})
+			return
+		}
+
+		user, err := lookup(c).user(organizationID, userID)
+		exitOnError(err)
+		printOutput(user)
+	},
+}
+
+var createUserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Add a user to an organization. The user is emailed an invitation to set their password.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		user, err := c.CreateUser(organizationID, client.UserParams{
+			Email:     &userEmail,
+			FirstName: changedString(cmd, "first-name", userFirstName),
+			LastName:  changedString(cmd, "last-name", userLastName),
+		})
+		exitOnError(err)
+		printOutput(user)
+	},
+}
+
+var deleteUserCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Remove a user with a specified identifier or email address from an organization.",
+	Run: func(cmd *cobra.Command, args []string) {
+		c := newClient()
+
+		var err error
+		userID, err = lookup(c).userID(organizationID, userID)
+		exitOnError(err)
+
+		user, err := c.DeleteUser(organizationID, userID)
+		exitOnError(err)
+		printOutput(user)
+	},
+}
+
+func init() {
+	getUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	getUserCmd.Flags().StringVar(&organizationID, "organization-id", "", "Organization identifier (required)")
+	getUserCmd.Flags().StringVarP(&userID, "id", "i", "", "User identifier or email address")
+	getUserCmd.MarkFlagRequired("organization-id")
+	addListFlags(getUserCmd)
+	createUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	createUserCmd.Flags().StringVar(&organizationID, "organization-id", "", "Organization identifier (required)")
+	createUserCmd.Flags().StringVarP(&userEmail, "email", "e", "", "Email address of the user (required)")
+	createUserCmd.Flags().StringVar(&userFirstName, "first-name", "", "First name of the user")
+	createUserCmd.Flags().StringVar(&userLastName, "last-name", "", "Last name of the user")
+	createUserCmd.MarkFlagRequired("organization-id")
+	createUserCmd.MarkFlagRequired("email")
+	deleteUserCmd.Flags().StringVarP(&apiKey, "api-key", "k", "", "API_KEY environment variable. API key to authenticate against the status page API (required)")
+	deleteUserCmd.Flags().StringVar(&organizationID, "organization-id", "", "Organization identifier (required)")
+	deleteUserCmd.Flags().StringVarP(&userID, "id", "i", "", "User identifier or email address (required)")
+	deleteUserCmd.MarkFlagRequired("organization-id")
+	deleteUserCmd.MarkFlagRequired("id")
+	getCmd.AddCommand(getUserCmd)
+	createCmd.AddCommand(createUserCmd)
+	deleteCmd.AddCommand(deleteUserCmd)
+}
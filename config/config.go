@@ -0,0 +1,140 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config reads and writes the statuspage CLI configuration file.
+package config
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultProfile is the profile used when none has been selected.
+const DefaultProfile = "default"
+
+// Keys are the settings a profile can hold.
+var Keys = []string{"api_key", "page_id", "organization_id", "base_url", "output"}
+
+// Profile holds the settings used to talk to one Statuspage page and its organization.
+type Profile struct {
+	APIKey         string `yaml:"api_key,omitempty"`
+	PageID         string `yaml:"page_id,omitempty"`
+	OrganizationID string `yaml:"organization_id,omitempty"`
+	BaseURL        string `yaml:"base_url,omitempty"`
+	Output         string `yaml:"output,omitempty"`
+}
+
+// Config is the content of the configuration file.
+type Config struct {
+	CurrentProfile string              `yaml:"current_profile,omitempty"`
+	Profiles       map[string]*Profile `yaml:"profiles,omitempty"`
+}
+
+// Load reads the configuration file at path. A missing file is an empty configuration.
+func Load(path string) (*Config, error) {
+	cfg := &Config{Profiles: map[string]*Profile{}}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if cfg.Profiles == nil {
+		cfg.Profiles = map[string]*Profile{}
+	}
+	return cfg, nil
+}
+
+// Save writes the configuration to path. The file is only readable by its owner as it holds API keys.
+func (c *Config) Save(path string) error {
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
+
+// Profile returns the profile called name. When name is empty the current profile is returned,
+// or an empty profile when none is set.
+func (c *Config) Profile(name string) (*Profile, error) {
+	if name == "" {
+		name = c.CurrentProfile
+		if name == "" {
+			return &Profile{}, nil
+		}
+	}
+
+	profile, ok := c.Profiles[name]
+	if !ok {
+		return nil, fmt.Errorf("profile %q not found in the configuration file", name)
+	}
+	return profile, nil
+}
+
+// ProfileNames returns the names of the profiles in alphabetical order.
+func (c *Config) ProfileNames() []string {
+	names := make([]string, 0, len(c.Profiles))
+	for name := range c.Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Get returns the value of a setting of the profile.
+func (p *Profile) Get(key string) (string, error) {
+	field, err := p.field(key)
+	if err != nil {
+		return "", err
+	}
+	return *field, nil
+}
+
+// Set changes the value of a setting of the profile.
+func (p *Profile) Set(key, value string) error {
+	field, err := p.field(key)
+	if err != nil {
+		return err
+	}
+	*field = value
+	return nil
+}
+
+func (p *Profile) field(key string) (*string, error) {
+	switch key {
+	case "api_key":
+		return &p.APIKey, nil
+	case "page_id":
+		return &p.PageID, nil
+	case "organization_id":
+		return &p.OrganizationID, nil
+	case "base_url":
+		return &p.BaseURL, nil
+	case "output":
+		return &p.Output, nil
+	}
+	return nil, fmt.Errorf("unknown setting %q. Valid settings are: %s", key, strings.Join(Keys, ", "))
+}
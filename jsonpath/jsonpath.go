@@ -0,0 +1,529 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package jsonpath evaluates the JSONPath templates accepted by kubectl, such as
+// {.items[*].name} or {range .items[*]}{.id}{"\n"}{end}, against decoded JSON.
+//
+// Paths support fields (.name and ['name']), wildcards (.* and [*]), indexes ([0] and [-1]),
+// slices ([1:3]), recursive descent (..name) and filters ([?(@.status=="operational")] and
+// [?(@.group)]). Fields missing from the data produce no result rather than an error.
+package jsonpath
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Template is a parsed JSONPath template.
+type Template struct {
+	nodes []node
+}
+
+type node interface{}
+
+// textNode is literal text, from outside braces or a quoted string within them.
+type textNode string
+
+// pathNode prints the results of a path, separated by spaces.
+type pathNode []step
+
+// rangeNode executes its body with each result of its path as the current value.
+type rangeNode struct {
+	path []step
+	body []node
+}
+
+type stepKind int
+
+const (
+	fieldStep stepKind = iota
+	wildcardStep
+	indexStep
+	sliceStep
+	recursiveStep
+	filterStep
+	rootStep
+)
+
+type step struct {
+	kind  stepKind
+	name  string
+	index int
+	// start and end bound slices, nil when left out.
+	start, end *int
+	filter     *filter
+}
+
+type filter struct {
+	path     []step
+	operator string
+	operand  interface{}
+}
+
+// Parse parses a template. Text outside braces is printed as it is.
+func Parse(text string) (*Template, error) {
+	stack := [][]node{{}}
+	for len(text) > 0 {
+		open := strings.IndexByte(text, '{')
+		if open < 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], textNode(text))
+			break
+		}
+		if open > 0 {
+			stack[len(stack)-1] = append(stack[len(stack)-1], textNode(text[:open]))
+		}
+
+		end := closing(text, open, '{', '}')
+		if end < 0 {
+			return nil, fmt.Errorf("unclosed action in %q", text[open:])
+		}
+		action := strings.TrimSpace(text[open+1 : end])
+		text = text[end+1:]
+
+		switch {
+		case action == "end":
+			if len(stack) == 1 {
+				return nil, fmt.Errorf("{end} without {range}")
+			}
+			body := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			r := stack[len(stack)-1][len(stack[len(stack)-1])-1].(*rangeNode)
+			r.body = body
+		case strings.HasPrefix(action, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(action, "range ")))
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], &rangeNode{path: path})
+			stack = append(stack, []node{})
+		case strings.HasPrefix(action, `"`):
+			s, err := strconv.Unquote(action)
+			if err != nil {
+				return nil, fmt.Errorf("invalid string %s", action)
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], textNode(s))
+		default:
+			path, err := parsePath(action)
+			if err != nil {
+				return nil, err
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], pathNode(path))
+		}
+	}
+
+	if len(stack) > 1 {
+		return nil, fmt.Errorf("{range} without {end}")
+	}
+	return &Template{nodes: stack[0]}, nil
+}
+
+// Execute prints the template filled in with data, the result of decoding JSON into an interface{}.
+func (t *Template) Execute(w io.Writer, data interface{}) error {
+	return execute(w, t.nodes, data, data)
+}
+
+func execute(w io.Writer, nodes []node, root, current interface{}) error {
+	for _, n := range nodes {
+		switch n := n.(type) {
+		case textNode:
+			if _, err := io.WriteString(w, string(n)); err != nil {
+				return err
+			}
+		case pathNode:
+			values := []string{}
+			for _, v := range eval(n, root, current) {
+				values = append(values, format(v))
+			}
+			if _, err := io.WriteString(w, strings.Join(values, " ")); err != nil {
+				return err
+			}
+		case *rangeNode:
+			for _, v := range eval(n.path, root, current) {
+				if err := execute(w, n.body, root, v); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// closing returns the index of the bracket closing the one at open, skipping quoted text and
+// nested brackets, or -1.
+func closing(s string, open int, left, right byte) int {
+	depth := 0
+	var quote byte
+	for i := open; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case quote != 0:
+			if c == '\\' {
+				i++
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == left:
+			depth++
+		case c == right:
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func parsePath(s string) ([]step, error) {
+	steps := []step{}
+	i := 0
+	if strings.HasPrefix(s, "$") {
+		steps = append(steps, step{kind: rootStep})
+		i++
+	} else if strings.HasPrefix(s, "@") {
+		i++
+	}
+
+	for i < len(s) {
+		switch {
+		case strings.HasPrefix(s[i:], ".."):
+			name, n := identifier(s[i+2:])
+			if name == "" {
+				return nil, fmt.Errorf("missing field name after .. in %q", s)
+			}
+			steps = append(steps, step{kind: recursiveStep, name: name})
+			i += 2 + n
+		case strings.HasPrefix(s[i:], ".*"):
+			steps = append(steps, step{kind: wildcardStep})
+			i += 2
+		case s[i] == '.':
+			i++
+		case s[i] == '[':
+			end := closing(s, i, '[', ']')
+			if end < 0 {
+				return nil, fmt.Errorf("unclosed [ in %q", s)
+			}
+			st, err := parseBracket(strings.TrimSpace(s[i+1 : end]))
+			if err != nil {
+				return nil, err
+			}
+			steps = append(steps, st)
+			i = end + 1
+		default:
+			name, n := identifier(s[i:])
+			if name == "" {
+				return nil, fmt.Errorf("unexpected %q in %q", s[i:], s)
+			}
+			steps = append(steps, step{kind: fieldStep, name: name})
+			i += n
+		}
+	}
+	return steps, nil
+}
+
+// identifier returns the field name at the start of s and its length.
+func identifier(s string) (string, int) {
+	n := strings.IndexAny(s, ".[ ")
+	if n < 0 {
+		n = len(s)
+	}
+	return s[:n], n
+}
+
+func parseBracket(s string) (step, error) {
+	switch {
+	case s == "*":
+		return step{kind: wildcardStep}, nil
+	case strings.HasPrefix(s, "?(") && strings.HasSuffix(s, ")"):
+		f, err := parseFilter(strings.TrimSpace(s[2 : len(s)-1]))
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: filterStep, filter: f}, nil
+	case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+		name, err := unquote(s)
+		if err != nil {
+			return step{}, err
+		}
+		return step{kind: fieldStep, name: name}, nil
+	case strings.Contains(s, ":"):
+		bounds := strings.SplitN(s, ":", 2)
+		st := step{kind: sliceStep}
+		for i, b := range bounds {
+			if b = strings.TrimSpace(b); b == "" {
+				continue
+			}
+			n, err := strconv.Atoi(b)
+			if err != nil {
+				return step{}, fmt.Errorf("invalid slice [%s]", s)
+			}
+			if i == 0 {
+				st.start = &n
+			} else {
+				st.end = &n
+			}
+		}
+		return st, nil
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return step{}, fmt.Errorf("invalid index [%s]", s)
+	}
+	return step{kind: indexStep, index: n}, nil
+}
+
+var operators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+func parseFilter(s string) (*filter, error) {
+	for i := 0; i < len(s); i++ {
+		if s[i] == '"' || s[i] == '\'' {
+			// Operands are on the right, so a quote means there is no operator.
+			break
+		}
+		for _, op := range operators {
+			if strings.HasPrefix(s[i:], op) {
+				path, err := parsePath(strings.TrimSpace(s[:i]))
+				if err != nil {
+					return nil, err
+				}
+				operand, err := parseOperand(strings.TrimSpace(s[i+len(op):]))
+				if err != nil {
+					return nil, err
+				}
+				return &filter{path: path, operator: op, operand: operand}, nil
+			}
+		}
+	}
+
+	path, err := parsePath(s)
+	if err != nil {
+		return nil, err
+	}
+	return &filter{path: path}, nil
+}
+
+func parseOperand(s string) (interface{}, error) {
+	switch {
+	case strings.HasPrefix(s, "'") || strings.HasPrefix(s, `"`):
+		return unquote(s)
+	case s == "true":
+		return true, nil
+	case s == "false":
+		return false, nil
+	case s == "null":
+		return nil, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid filter value %s", s)
+	}
+	return f, nil
+}
+
+// unquote returns the content of a single or double quoted string.
+func unquote(s string) (string, error) {
+	if strings.HasPrefix(s, "'") && strings.HasSuffix(s, "'") && len(s) >= 2 {
+		return s[1 : len(s)-1], nil
+	}
+	u, err := strconv.Unquote(s)
+	if err != nil {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+	return u, nil
+}
+
+func eval(steps []step, root, current interface{}) []interface{} {
+	values := []interface{}{current}
+	for _, st := range steps {
+		next := []interface{}{}
+		for _, v := range values {
+			next = append(next, apply(st, root, v)...)
+		}
+		values = next
+	}
+	return values
+}
+
+func apply(st step, root, v interface{}) []interface{} {
+	switch st.kind {
+	case rootStep:
+		return []interface{}{root}
+	case fieldStep:
+		if m, ok := v.(map[string]interface{}); ok {
+			if field, ok := m[st.name]; ok {
+				return []interface{}{field}
+			}
+		}
+	case wildcardStep:
+		return children(v)
+	case indexStep:
+		if list, ok := v.([]interface{}); ok {
+			i := st.index
+			if i < 0 {
+				i += len(list)
+			}
+			if i >= 0 && i < len(list) {
+				return []interface{}{list[i]}
+			}
+		}
+	case sliceStep:
+		if list, ok := v.([]interface{}); ok {
+			start, end := bound(st.start, 0, len(list)), bound(st.end, len(list), len(list))
+			if start < end {
+				return list[start:end]
+			}
+		}
+	case recursiveStep:
+		found := []interface{}{}
+		descend(v, func(m map[string]interface{}) {
+			if field, ok := m[st.name]; ok {
+				found = append(found, field)
+			}
+		})
+		return found
+	case filterStep:
+		matched := []interface{}{}
+		for _, child := range children(v) {
+			if st.filter.match(root, child) {
+				matched = append(matched, child)
+			}
+		}
+		return matched
+	}
+	return nil
+}
+
+// children returns the elements of a list, or the values of a map ordered by key.
+func children(v interface{}) []interface{} {
+	switch v := v.(type) {
+	case []interface{}:
+		return v
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		values := []interface{}{}
+		for _, k := range keys {
+			values = append(values, v[k])
+		}
+		return values
+	}
+	return nil
+}
+
+func descend(v interface{}, fn func(map[string]interface{})) {
+	if m, ok := v.(map[string]interface{}); ok {
+		fn(m)
+	}
+	for _, child := range children(v) {
+		descend(child, fn)
+	}
+}
+
+func bound(b *int, def, length int) int {
+	if b == nil {
+		return def
+	}
+	n := *b
+	if n < 0 {
+		n += length
+	}
+	if n < 0 {
+		return 0
+	}
+	if n > length {
+		return length
+	}
+	return n
+}
+
+func (f *filter) match(root, v interface{}) bool {
+	values := eval(f.path, root, v)
+	if len(values) == 0 {
+		return false
+	}
+	left := values[0]
+	if f.operator == "" {
+		return left != nil && left != false
+	}
+
+	switch f.operator {
+	case "==":
+		return equal(left, f.operand)
+	case "!=":
+		return !equal(left, f.operand)
+	}
+
+	switch l := left.(type) {
+	case float64:
+		r, ok := f.operand.(float64)
+		return ok && compare(l < r, l == r, f.operator)
+	case string:
+		r, ok := f.operand.(string)
+		return ok && compare(l < r, l == r, f.operator)
+	}
+	return false
+}
+
+// equal compares scalars. Lists and objects are never equal to a filter value.
+func equal(a, b interface{}) bool {
+	switch a.(type) {
+	case nil, string, float64, bool:
+		return a == b
+	}
+	return false
+}
+
+func compare(less, equal bool, operator string) bool {
+	switch operator {
+	case "<":
+		return less
+	case "<=":
+		return less || equal
+	case ">":
+		return !less && !equal
+	case ">=":
+		return !less
+	}
+	return false
+}
+
+// format prints strings as they are, numbers without exponents and lists and objects as JSON.
+func format(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	}
+	out, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	return string(out)
+}
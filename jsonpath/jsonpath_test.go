@@ -0,0 +1,95 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package jsonpath
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+)
+
+const components = `[
+	{"id": "a1", "name": "API", "status": "operational", "position": 1, "group": false},
+	{"id": "b2", "name": "Web", "status": "major_outage", "position": 2, "group": false},
+	{"id": "c3", "name": "Europe", "status": "operational", "position": 3, "group": true, "components": ["a1", "b2"]}
+]`
+
+func TestExecute(t *testing.T) {
+	var data interface{}
+	if err := json.Unmarshal([]byte(components), &data); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		template string
+		want     string
+	}{
+		{`{[*].name}`, "API Web Europe"},
+		{`{.[*].id}`, "a1 b2 c3"},
+		{`{$[0].name}`, "API"},
+		{`{[-1].name}`, "Europe"},
+		{`{[0:2].id}`, "a1 b2"},
+		{`{[1:].id}`, "b2 c3"},
+		{`{[0]['name']}`, "API"},
+		{`{[?(@.name=="Web")].id}`, "b2"},
+		{`{[?(@.status!='operational')].name}`, "Web"},
+		{`{[?(@.position>=2)].id}`, "b2 c3"},
+		{`{[?(@.position<2)].id}`, "a1"},
+		{`{[?(@.group)].name}`, "Europe"},
+		{`{[?(@.components)].id}`, "c3"},
+		{`{[?(@.components=="a1")].id}`, ""},
+		{`{..components}`, `["a1","b2"]`},
+		{`{[2].components[1]}`, "b2"},
+		{`{[0].position}`, "1"},
+		{`{[0].group}`, "false"},
+		{`{[0].missing}`, ""},
+		{`{range [*]}{.id}={.status}{"\n"}{end}`, "a1=operational\nb2=major_outage\nc3=operational\n"},
+		{`names: {[*].name}!`, "names: API Web Europe!"},
+		{`{range [?(@.group)]}{range .components[*]}[{@}]{end}{end}`, "[a1][b2]"},
+	}
+
+	for _, test := range tests {
+		tmpl, err := Parse(test.template)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", test.template, err)
+			continue
+		}
+		var out bytes.Buffer
+		if err := tmpl.Execute(&out, data); err != nil {
+			t.Errorf("Execute(%q) returned error: %v", test.template, err)
+			continue
+		}
+		if out.String() != test.want {
+			t.Errorf("Execute(%q) = %q, want %q", test.template, out.String(), test.want)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, template := range []string{
+		`{[*].name`,
+		`{range [*]}{.id}`,
+		`{end}`,
+		`{[abc]}`,
+		`{[?(@.position>abc)]}`,
+		`{..}`,
+		`{"unterminated}`,
+	} {
+		if _, err := Parse(template); err == nil {
+			t.Errorf("Parse(%q) returned no error", template)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"fmt"
+	"io"
+)
+
+const (
+	colorRed   = "\x1b[31m"
+	colorGreen = "\x1b[32m"
+	colorCyan  = "\x1b[36m"
+	colorReset = "\x1b[0m"
+)
+
+// WriteDiff writes changes as a unified diff from the page to the manifest: resources that are
+// created are added lines, resources that are deleted are removed lines, and updated resources
+// show the old and new value of each changed field. Lines are coloured when color is set.
+func WriteDiff(w io.Writer, changes []Change, color bool) {
+	paint := func(code, line string) {
+		if color {
+			line = code + line + colorReset
+		}
+		fmt.Fprintln(w, line)
+	}
+
+	paint(colorRed, "--- page")
+	paint(colorGreen, "+++ manifest")
+
+	var created, updated, deleted int
+	for _, change := range changes {
+		header := fmt.Sprintf("%s %q", change.Kind, change.Name)
+
+		switch change.Action {
+		case Create:
+			created++
+			paint(colorGreen, "+ "+header)
+			for _, field := range change.Fields {
+				paint(colorGreen, fmt.Sprintf("+     %s: %q", field.Field, field.New))
+			}
+		case Delete:
+			deleted++
+			paint(colorRed, "- "+header)
+			for _, field := range change.Fields {
+				paint(colorRed, fmt.Sprintf("-     %s: %q", field.Field, field.Old))
+			}
+		case Update:
+			updated++
+			paint(colorCyan, "  "+header)
+			for _, field := range change.Fields {
+				paint(colorRed, fmt.Sprintf("-     %s: %q", field.Field, field.Old))
+				paint(colorGreen, fmt.Sprintf("+     %s: %q", field.Field, field.New))
+			}
+		}
+	}
+
+	fmt.Fprintf(w, "%d to create, %d to update, %d to delete.\n", created, updated, deleted)
+}
@@ -0,0 +1,175 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"../client"
+	"gopkg.in/yaml.v2"
+	"io"
+	"sort"
+)
+
+// Export returns a manifest recreating page. Identifiers, timestamps and positions are left out:
+// resources are referred to by name and listed in the order they appear on the page.
+func Export(page *Page) (*Manifest, error) {
+	// Manifests match resources by name, so names must be unique for the export to be applied.
+	if _, err := liveComponents(page); err != nil {
+		return nil, err
+	}
+	if _, err := liveGroups(page); err != nil {
+		return nil, err
+	}
+	if _, err := liveMetrics(page); err != nil {
+		return nil, err
+	}
+	if _, err := liveTemplates(page); err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+
+	if s := page.Settings; s != nil {
+		m.Page = &PageSettings{
+			Name:                     optionalString(s.Name),
+			Branding:                 optionalString(s.Branding),
+			Subdomain:                optionalString(s.Subdomain),
+			Domain:                   optionalString(s.Domain),
+			URL:                      optionalString(s.URL),
+			SupportURL:               optionalString(s.SupportURL),
+			TimeZone:                 optionalString(s.TimeZone),
+			AllowPageSubscribers:     optionalBool(s.AllowPageSubscribers),
+			AllowIncidentSubscribers: optionalBool(s.AllowIncidentSubscribers),
+			AllowEmailSubscribers:    optionalBool(s.AllowEmailSubscribers),
+			AllowSMSSubscribers:      optionalBool(s.AllowSMSSubscribers),
+			AllowRSSAtomFeeds:        optionalBool(s.AllowRSSAtomFeeds),
+			AllowWebhookSubscribers:  optionalBool(s.AllowWebhookSubscribers),
+			HiddenFromSearch:         optionalBool(s.HiddenFromSearch),
+			ViewersMustBeTeamMembers: optionalBool(s.ViewersMustBeTeamMembers),
+			NotificationsFromEmail:   optionalString(s.NotificationsFromEmail),
+			NotificationsEmailFooter: optionalString(s.NotificationsEmailFooter),
+			CSSBodyBackgroundColor:   optionalString(s.CSSBodyBackgroundColor),
+			CSSFontColor:             optionalString(s.CSSFontColor),
+			CSSLightFontColor:        optionalString(s.CSSLightFontColor),
+			CSSGreens:                optionalString(s.CSSGreens),
+			CSSYellows:               optionalString(s.CSSYellows),
+			CSSOranges:               optionalString(s.CSSOranges),
+			CSSBlues:                 optionalString(s.CSSBlues),
+			CSSReds:                  optionalString(s.CSSReds),
+			CSSBorderColor:           optionalString(s.CSSBorderColor),
+			CSSGraphColor:            optionalString(s.CSSGraphColor),
+			CSSLinkColor:             optionalString(s.CSSLinkColor),
+			CSSNoData:                optionalString(s.CSSNoData),
+		}
+	}
+
+	groups := append([]client.ComponentGroup{}, page.Groups...)
+	sort.SliceStable(groups, func(i, j int) bool { return groups[i].Position < groups[j].Position })
+	groupNames := map[string]string{}
+	for _, g := range groups {
+		groupNames[g.ID] = g.Name
+		group := Group{Name: g.Name}
+		if g.Description != "" {
+			group.Description = optionalString(g.Description)
+		}
+		m.Groups = append(m.Groups, group)
+	}
+
+	components := append([]client.Component{}, page.Components...)
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Position < components[j].Position })
+	for _, c := range components {
+		if c.Group {
+			continue
+		}
+		component := Component{
+			Name:               c.Name,
+			Description:        optionalString(c.Description),
+			Group:              groupNames[c.GroupID],
+			Showcase:           optionalBool(c.Showcase),
+			OnlyShowIfDegraded: optionalBool(c.OnlyShowIfDegraded),
+		}
+		if c.StartDate != "" {
+			component.StartDate = optionalString(c.StartDate)
+		}
+		m.Components = append(m.Components, component)
+	}
+
+	providers := map[string]string{}
+	for _, p := range page.Providers {
+		providers[p.ID] = p.Type
+	}
+	for _, metric := range page.Metrics {
+		m.Metrics = append(m.Metrics, Metric{
+			Name:               metric.Name,
+			Provider:           providers[metric.MetricsProviderID],
+			MetricIdentifier:   metric.MetricIdentifier,
+			Suffix:             optionalString(metric.Suffix),
+			Display:            optionalBool(metric.Display),
+			TooltipDescription: optionalString(metric.TooltipDescription),
+			DecimalPlaces:      optionalInt(metric.DecimalPlaces),
+			YAxisMin:           optionalFloat(metric.YAxisMin),
+			YAxisMax:           optionalFloat(metric.YAxisMax),
+			YAxisHidden:        optionalBool(metric.YAxisHidden),
+		})
+	}
+
+	for _, t := range page.IncidentTemplates {
+		template := IncidentTemplate{
+			Name:                    t.Name,
+			Title:                   t.Title,
+			Body:                    t.Body,
+			UpdateStatus:            t.UpdateStatus,
+			ShouldTweet:             t.ShouldTweet,
+			ShouldSendNotifications: t.ShouldSendNotifications,
+		}
+		for _, c := range t.Components {
+			template.Components = append(template.Components, c.Name)
+		}
+		m.IncidentTemplates = append(m.IncidentTemplates, template)
+	}
+
+	m.Subscribers = &Subscribers{Count: page.SubscriberCount}
+
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// Write writes the manifest as YAML.
+func (m *Manifest) Write(w io.Writer) error {
+	data, err := yaml.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func optionalString(s string) *string {
+	return &s
+}
+
+func optionalBool(b bool) *bool {
+	return &b
+}
+
+func optionalInt(i int) *int {
+	return &i
+}
+
+func optionalFloat(f float64) *float64 {
+	return &f
+}
@@ -0,0 +1,71 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"../client"
+	"bytes"
+	"gopkg.in/yaml.v2"
+	"testing"
+)
+
+func TestExportApplies(t *testing.T) {
+	page := livePage()
+	page.Metrics[0].MetricsProviderID = "mp1"
+	page.Providers = []client.MetricsProvider{{ID: "mp1", Type: "Pingdom"}}
+
+	m, err := Export(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Metrics[0].Provider != "Pingdom" {
+		t.Errorf("exported metric provider %q, want Pingdom", m.Metrics[0].Provider)
+	}
+
+	// The exported manifest is read back as written, and matches the page it was exported from.
+	var out bytes.Buffer
+	if err := m.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	read := &Manifest{}
+	if err := yaml.UnmarshalStrict(out.Bytes(), read); err != nil {
+		t.Fatal(err)
+	}
+	changes, err := Plan(read, page, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) > 0 {
+		t.Errorf("export of the page differs from the page: %+v", changes)
+	}
+
+	// Applied to an empty page, it creates every resource.
+	empty := &Page{Settings: &client.Page{ID: "pppppppppp02"}}
+	changes, err = Plan(read, empty, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	created := map[Kind]int{}
+	for _, change := range changes {
+		created[change.Kind]++
+	}
+	want := map[Kind]int{KindPage: 1, KindComponent: 3, KindGroup: 1, KindMetric: 2, KindTemplate: 1}
+	for kind, n := range want {
+		if created[kind] != n {
+			t.Errorf("got %d %s changes on an empty page, want %d", created[kind], kind, n)
+		}
+	}
+}
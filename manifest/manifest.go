@@ -0,0 +1,272 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package manifest reads manifests declaring the components of a page and works out the changes
+// needed to make a page match them, and exports the configuration of a page as a manifest.
+package manifest
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+)
+
+// Manifest declares the component groups, components and metrics of a page, and optionally its
+// settings and incident templates. Exported manifests also record the number of subscribers.
+type Manifest struct {
+	Page              *PageSettings      `yaml:"page,omitempty"`
+	Groups            []Group            `yaml:"groups,omitempty"`
+	Components        []Component        `yaml:"components,omitempty"`
+	Metrics           []Metric           `yaml:"metrics,omitempty"`
+	IncidentTemplates []IncidentTemplate `yaml:"incident_templates,omitempty"`
+	Subscribers       *Subscribers       `yaml:"subscribers,omitempty"`
+}
+
+// PageSettings are the settings of the page itself. Settings left unset are not managed by the manifest.
+type PageSettings struct {
+	Name                     *string `yaml:"name,omitempty"`
+	Subdomain                *string `yaml:"subdomain,omitempty"`
+	Domain                   *string `yaml:"domain,omitempty"`
+	URL                      *string `yaml:"url,omitempty"`
+	Branding                 *string `yaml:"branding,omitempty"`
+	SupportURL               *string `yaml:"support_url,omitempty"`
+	TimeZone                 *string `yaml:"time_zone,omitempty"`
+	AllowPageSubscribers     *bool   `yaml:"allow_page_subscribers,omitempty"`
+	AllowIncidentSubscribers *bool   `yaml:"allow_incident_subscribers,omitempty"`
+	AllowEmailSubscribers    *bool   `yaml:"allow_email_subscribers,omitempty"`
+	AllowSMSSubscribers      *bool   `yaml:"allow_sms_subscribers,omitempty"`
+	AllowRSSAtomFeeds        *bool   `yaml:"allow_rss_atom_feeds,omitempty"`
+	AllowWebhookSubscribers  *bool   `yaml:"allow_webhook_subscribers,omitempty"`
+	HiddenFromSearch         *bool   `yaml:"hidden_from_search,omitempty"`
+	ViewersMustBeTeamMembers *bool   `yaml:"viewers_must_be_team_members,omitempty"`
+	NotificationsFromEmail   *string `yaml:"notifications_from_email,omitempty"`
+	NotificationsEmailFooter *string `yaml:"notifications_email_footer,omitempty"`
+	CSSBodyBackgroundColor   *string `yaml:"css_body_background_color,omitempty"`
+	CSSFontColor             *string `yaml:"css_font_color,omitempty"`
+	CSSLightFontColor        *string `yaml:"css_light_font_color,omitempty"`
+	CSSGreens                *string `yaml:"css_greens,omitempty"`
+	CSSYellows               *string `yaml:"css_yellows,omitempty"`
+	CSSOranges               *string `yaml:"css_oranges,omitempty"`
+	CSSBlues                 *string `yaml:"css_blues,omitempty"`
+	CSSReds                  *string `yaml:"css_reds,omitempty"`
+	CSSBorderColor           *string `yaml:"css_border_color,omitempty"`
+	CSSGraphColor            *string `yaml:"css_graph_color,omitempty"`
+	CSSLinkColor             *string `yaml:"css_link_color,omitempty"`
+	CSSNoData                *string `yaml:"css_no_data,omitempty"`
+}
+
+// Group is a component group. Groups referred to by components do not have to be declared
+// unless they have a description.
+type Group struct {
+	Name        string  `yaml:"name"`
+	Description *string `yaml:"description,omitempty"`
+}
+
+// Component is a component. Optional settings left unset are not managed by the manifest.
+type Component struct {
+	Name               string  `yaml:"name"`
+	Description        *string `yaml:"description,omitempty"`
+	Group              string  `yaml:"group,omitempty"`
+	Showcase           *bool   `yaml:"showcase,omitempty"`
+	OnlyShowIfDegraded *bool   `yaml:"only_show_if_degraded,omitempty"`
+	StartDate          *string `yaml:"start_date,omitempty"`
+}
+
+// Metric is a system metric. Optional settings left unset are not managed by the manifest.
+// Provider and MetricIdentifier are only used to create the metric: Provider is the type of the
+// metrics provider of the page feeding it, Self when unset, and MetricIdentifier the identifier
+// of the metric in the monitoring service.
+type Metric struct {
+	Name               string   `yaml:"name"`
+	Provider           string   `yaml:"provider,omitempty"`
+	MetricIdentifier   string   `yaml:"metric_identifier,omitempty"`
+	Suffix             *string  `yaml:"suffix,omitempty"`
+	Display            *bool    `yaml:"display,omitempty"`
+	TooltipDescription *string  `yaml:"tooltip_description,omitempty"`
+	DecimalPlaces      *int     `yaml:"decimal_places,omitempty"`
+	YAxisMin           *float64 `yaml:"y_axis_min,omitempty"`
+	YAxisMax           *float64 `yaml:"y_axis_max,omitempty"`
+	YAxisHidden        *bool    `yaml:"y_axis_hidden,omitempty"`
+}
+
+// IncidentTemplate is an incident template. Components are the names of the components the
+// template affects.
+type IncidentTemplate struct {
+	Name                    string   `yaml:"name"`
+	Title                   string   `yaml:"title,omitempty"`
+	Body                    string   `yaml:"body,omitempty"`
+	UpdateStatus            string   `yaml:"update_status,omitempty"`
+	Components              []string `yaml:"components,omitempty"`
+	ShouldTweet             bool     `yaml:"should_tweet"`
+	ShouldSendNotifications bool     `yaml:"should_send_notifications"`
+}
+
+// Subscribers records the number of subscribers of an exported page. Subscribers themselves are
+// not part of manifests.
+type Subscribers struct {
+	Count int `yaml:"count"`
+}
+
+// Load reads a YAML or JSON manifest from path, or from stdin when path is "-".
+func Load(path string) (*Manifest, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = ioutil.ReadAll(os.Stdin)
+	} else {
+		data, err = ioutil.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{}
+	if err := yaml.UnmarshalStrict(data, m); err != nil {
+		return nil, fmt.Errorf("error reading manifest %s: %v", path, err)
+	}
+	if err := m.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid manifest %s: %v", path, err)
+	}
+	return m, nil
+}
+
+// Validate checks that every resource has a unique name, as resources are matched to the page by
+// name, and that every declared group has components, as the API rejects empty component groups.
+func (m *Manifest) Validate() error {
+	groups := map[string]bool{}
+	for i, g := range m.Groups {
+		if g.Name == "" {
+			return fmt.Errorf("group %d has no name", i+1)
+		}
+		if groups[g.Name] {
+			return fmt.Errorf("group %q is declared more than once", g.Name)
+		}
+		groups[g.Name] = true
+	}
+
+	components := map[string]bool{}
+	for i, c := range m.Components {
+		if c.Name == "" {
+			return fmt.Errorf("component %d has no name", i+1)
+		}
+		if components[c.Name] {
+			return fmt.Errorf("component %q is declared more than once", c.Name)
+		}
+		if groups[c.Name] {
+			return fmt.Errorf("%q is declared as both a component and a group", c.Name)
+		}
+		components[c.Name] = true
+	}
+	for _, g := range m.Groups {
+		if len(m.Members(g.Name)) == 0 {
+			return fmt.Errorf("group %q has no components, a component group needs at least one", g.Name)
+		}
+	}
+
+	metrics := map[string]bool{}
+	for i, metric := range m.Metrics {
+		if metric.Name == "" {
+			return fmt.Errorf("metric %d has no name", i+1)
+		}
+		if metrics[metric.Name] {
+			return fmt.Errorf("metric %q is declared more than once", metric.Name)
+		}
+		metrics[metric.Name] = true
+	}
+
+	templates := map[string]bool{}
+	for i, t := range m.IncidentTemplates {
+		if t.Name == "" {
+			return fmt.Errorf("incident template %d has no name", i+1)
+		}
+		if templates[t.Name] {
+			return fmt.Errorf("incident template %q is declared more than once", t.Name)
+		}
+		templates[t.Name] = true
+	}
+	return nil
+}
+
+// GroupNames returns the names of the declared groups followed by the groups only referred to by
+// components, in the order they appear in the manifest.
+func (m *Manifest) GroupNames() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, g := range m.Groups {
+		seen[g.Name] = true
+		names = append(names, g.Name)
+	}
+	for _, c := range m.Components {
+		if c.Group != "" && !seen[c.Group] {
+			seen[c.Group] = true
+			names = append(names, c.Group)
+		}
+	}
+	return names
+}
+
+// Group returns the declared group called name, or a group without a description when it is only
+// referred to by components.
+func (m *Manifest) Group(name string) Group {
+	for _, g := range m.Groups {
+		if g.Name == name {
+			return g
+		}
+	}
+	return Group{Name: name}
+}
+
+// Component returns the component called name.
+func (m *Manifest) Component(name string) (Component, bool) {
+	for _, c := range m.Components {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return Component{}, false
+}
+
+// Metric returns the metric called name.
+func (m *Manifest) Metric(name string) (Metric, bool) {
+	for _, metric := range m.Metrics {
+		if metric.Name == name {
+			return metric, true
+		}
+	}
+	return Metric{}, false
+}
+
+// IncidentTemplate returns the incident template called name.
+func (m *Manifest) IncidentTemplate(name string) (IncidentTemplate, bool) {
+	for _, t := range m.IncidentTemplates {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return IncidentTemplate{}, false
+}
+
+// Members returns the names of the components in the group called name.
+func (m *Manifest) Members(name string) []string {
+	members := []string{}
+	for _, c := range m.Components {
+		if c.Group == name {
+			members = append(members, c.Name)
+		}
+	}
+	return members
+}
@@ -0,0 +1,63 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest Manifest
+		err      string
+	}{
+		{"valid", Manifest{
+			Groups:     []Group{{Name: "Core"}},
+			Components: []Component{{Name: "API", Group: "Core"}, {Name: "Web", Group: "Edge"}},
+			Metrics:    []Metric{{Name: "Latency"}},
+		}, ""},
+		{"empty", Manifest{}, ""},
+		{"group without a name", Manifest{Groups: []Group{{}}}, "group 1 has no name"},
+		{"duplicate group", Manifest{
+			Groups:     []Group{{Name: "Core"}, {Name: "Core"}},
+			Components: []Component{{Name: "API", Group: "Core"}},
+		}, `group "Core" is declared more than once`},
+		{"group without components", Manifest{
+			Groups:     []Group{{Name: "Core"}, {Name: "Empty"}},
+			Components: []Component{{Name: "API", Group: "Core"}},
+		}, `group "Empty" has no components`},
+		{"component without a name", Manifest{Components: []Component{{Name: "API"}, {}}}, "component 2 has no name"},
+		{"duplicate component", Manifest{Components: []Component{{Name: "API"}, {Name: "API"}}}, `component "API" is declared more than once`},
+		{"component named as a group", Manifest{
+			Groups:     []Group{{Name: "API"}},
+			Components: []Component{{Name: "API", Group: "API"}},
+		}, `"API" is declared as both a component and a group`},
+		{"duplicate metric", Manifest{Metrics: []Metric{{Name: "Latency"}, {Name: "Latency"}}}, `metric "Latency" is declared more than once`},
+		{"duplicate incident template", Manifest{IncidentTemplates: []IncidentTemplate{{Name: "Outage"}, {Name: "Outage"}}}, `incident template "Outage" is declared more than once`},
+	}
+
+	for _, test := range tests {
+		err := test.manifest.Validate()
+		switch {
+		case test.err == "" && err != nil:
+			t.Errorf("%s: got error %v", test.name, err)
+		case test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)):
+			t.Errorf("%s: got error %v, want %q", test.name, err, test.err)
+		}
+	}
+}
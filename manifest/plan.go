@@ -0,0 +1,514 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"../client"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Action is what a change does to a resource.
+type Action string
+
+const (
+	Create Action = "create"
+	Update Action = "update"
+	Delete Action = "delete"
+)
+
+// Kind is the type of resource a change applies to.
+type Kind string
+
+const (
+	KindComponent Kind = "component"
+	KindGroup     Kind = "component group"
+	KindMetric    Kind = "metric"
+	KindPage      Kind = "page"
+	KindTemplate  Kind = "incident template"
+)
+
+// FieldChange is the change of a single field of a resource. Old is empty when the resource is
+// created and New is empty when it is deleted.
+type FieldChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// Change is a change needed to make a page match a manifest.
+type Change struct {
+	Action Action
+	Kind   Kind
+	Name   string
+	// ID is the identifier of the resource on the page, empty when it is created.
+	ID     string
+	Fields []FieldChange
+}
+
+// Page is the live state of a page that a manifest is compared with.
+type Page struct {
+	// Components are the components of the page, including the entries standing for component groups.
+	Components []client.Component
+	Groups     []client.ComponentGroup
+	Metrics    []client.Metric
+	Providers  []client.MetricsProvider
+
+	// Settings and IncidentTemplates are only fetched when the manifest declares them, and
+	// SubscriberCount for exports.
+	Settings          *client.Page
+	IncidentTemplates []client.IncidentTemplate
+	SubscriberCount   int
+}
+
+// Plan returns the changes needed to make page match m, in the order they must be applied: the
+// page settings are updated first, components are created and updated before the groups and
+// incident templates referring to them, and deleted before groups are deleted. Resources missing
+// from the manifest are only deleted when prune is set. Metrics, page settings and incident
+// templates are only compared when the manifest declares them, as most pages do not manage them.
+// Incident templates are only created: the templates on the page are neither compared nor pruned.
+func Plan(m *Manifest, page *Page, prune bool) ([]Change, error) {
+	components, err := liveComponents(page)
+	if err != nil {
+		return nil, err
+	}
+	groups, err := liveGroups(page)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := liveMetrics(page)
+	if err != nil {
+		return nil, err
+	}
+	templates, err := liveTemplates(page)
+	if err != nil {
+		return nil, err
+	}
+	names := map[string]string{}
+	for _, c := range page.Components {
+		names[c.ID] = c.Name
+	}
+
+	var changes []Change
+
+	if m.Page != nil && page.Settings != nil {
+		fields, err := pageFields(m.Page, page.Settings)
+		if err != nil {
+			return nil, err
+		}
+		if len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Kind: KindPage, Name: page.Settings.Name, ID: page.Settings.ID, Fields: fields})
+		}
+	}
+
+	for _, want := range m.Components {
+		have, ok := components[want.Name]
+		if !ok {
+			changes = append(changes, Change{Action: Create, Kind: KindComponent, Name: want.Name, Fields: componentFields(want, nil)})
+			continue
+		}
+		if fields := componentFields(want, have); len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Kind: KindComponent, Name: want.Name, ID: have.ID, Fields: fields})
+		}
+	}
+
+	for _, name := range m.GroupNames() {
+		want := m.Group(name)
+		members := m.Members(name)
+		have, ok := groups[name]
+		if !ok {
+			changes = append(changes, Change{Action: Create, Kind: KindGroup, Name: name, Fields: groupFields(want, members, nil, nil)})
+			continue
+		}
+		var haveMembers []string
+		for _, id := range have.Components {
+			haveMembers = append(haveMembers, names[id])
+		}
+		if fields := groupFields(want, members, have, haveMembers); len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Kind: KindGroup, Name: name, ID: have.ID, Fields: fields})
+		}
+	}
+
+	for _, want := range m.Metrics {
+		have, ok := metrics[want.Name]
+		if !ok {
+			changes = append(changes, Change{Action: Create, Kind: KindMetric, Name: want.Name, Fields: metricFields(want, nil)})
+			continue
+		}
+		if fields := metricFields(want, have); len(fields) > 0 {
+			changes = append(changes, Change{Action: Update, Kind: KindMetric, Name: want.Name, ID: have.ID, Fields: fields})
+		}
+	}
+
+	// The API documents no way to update incident templates, so the templates already on the page
+	// are left as they are rather than reported as differences apply cannot resolve.
+	for _, want := range m.IncidentTemplates {
+		if _, ok := templates[want.Name]; !ok {
+			changes = append(changes, Change{Action: Create, Kind: KindTemplate, Name: want.Name, Fields: templateFields(want)})
+		}
+	}
+
+	if !prune {
+		return changes, nil
+	}
+
+	for _, have := range sortedComponents(components) {
+		if _, ok := m.Component(have.Name); !ok {
+			changes = append(changes, Change{Action: Delete, Kind: KindComponent, Name: have.Name, ID: have.ID, Fields: deletedFields(componentFields(Component{}, have))})
+		}
+	}
+
+	declared := map[string]bool{}
+	for _, name := range m.GroupNames() {
+		declared[name] = true
+	}
+	for _, have := range sortedGroups(groups) {
+		if !declared[have.Name] {
+			var haveMembers []string
+			for _, id := range have.Components {
+				haveMembers = append(haveMembers, names[id])
+			}
+			changes = append(changes, Change{Action: Delete, Kind: KindGroup, Name: have.Name, ID: have.ID, Fields: deletedFields(groupFields(Group{}, nil, have, haveMembers))})
+		}
+	}
+
+	if len(m.Metrics) > 0 {
+		for _, have := range page.Metrics {
+			if _, ok := m.Metric(have.Name); !ok {
+				changes = append(changes, Change{Action: Delete, Kind: KindMetric, Name: have.Name, ID: have.ID, Fields: deletedFields(metricFields(Metric{}, &have))})
+			}
+		}
+	}
+	return changes, nil
+}
+
+// metricFields compares the settings of a metric managed by the manifest with the metric on the
+// page, which is nil when the metric is created. As for components, settings left unset are only
+// compared when the metric is deleted.
+func metricFields(want Metric, have *client.Metric) []FieldChange {
+	exists := have != nil
+	if have == nil {
+		have = &client.Metric{}
+	}
+
+	var fields []FieldChange
+	add := func(field string, managed bool, old, new string) {
+		if !exists {
+			old = ""
+		}
+		if (managed || want.Name == "") && old != new {
+			fields = append(fields, FieldChange{field, old, new})
+		}
+	}
+
+	if !exists && want.Name != "" {
+		fields = append(fields, FieldChange{"provider", "", metricProvider(want)})
+	}
+	add("suffix", want.Suffix != nil, have.Suffix, stringValue(want.Suffix))
+	add("display", want.Display != nil, strconv.FormatBool(have.Display), boolValue(want.Display))
+	add("tooltip_description", want.TooltipDescription != nil, have.TooltipDescription, stringValue(want.TooltipDescription))
+	add("decimal_places", want.DecimalPlaces != nil, strconv.Itoa(have.DecimalPlaces), intValue(want.DecimalPlaces))
+	add("y_axis_min", want.YAxisMin != nil, formatFloat(have.YAxisMin), floatValue(want.YAxisMin))
+	add("y_axis_max", want.YAxisMax != nil, formatFloat(have.YAxisMax), floatValue(want.YAxisMax))
+	add("y_axis_hidden", want.YAxisHidden != nil, strconv.FormatBool(have.YAxisHidden), boolValue(want.YAxisHidden))
+	return fields
+}
+
+// metricProvider returns the type of the metrics provider feeding a metric of the manifest.
+func metricProvider(want Metric) string {
+	if want.Provider == "" {
+		return "Self"
+	}
+	return want.Provider
+}
+
+// PageParams returns the settings of s listed in fields, as sent to update the page, so settings
+// already matching the page are not sent again.
+func PageParams(s *PageSettings, fields []FieldChange) (client.PageParams, error) {
+	params := client.PageParams{}
+	all, err := jsonFields(s.pageParams())
+	if err != nil {
+		return params, err
+	}
+	changed := map[string]interface{}{}
+	for _, field := range fields {
+		changed[field.Field] = all[field.Field]
+	}
+	data, err := json.Marshal(changed)
+	if err != nil {
+		return params, err
+	}
+	return params, json.Unmarshal(data, &params)
+}
+
+// pageParams returns the page settings managed by the manifest, as sent to update the page.
+func (s *PageSettings) pageParams() client.PageParams {
+	return client.PageParams{
+		Name:                     s.Name,
+		Subdomain:                s.Subdomain,
+		Domain:                   s.Domain,
+		URL:                      s.URL,
+		Branding:                 s.Branding,
+		SupportURL:               s.SupportURL,
+		TimeZone:                 s.TimeZone,
+		AllowPageSubscribers:     s.AllowPageSubscribers,
+		AllowIncidentSubscribers: s.AllowIncidentSubscribers,
+		AllowEmailSubscribers:    s.AllowEmailSubscribers,
+		AllowSMSSubscribers:      s.AllowSMSSubscribers,
+		AllowRSSAtomFeeds:        s.AllowRSSAtomFeeds,
+		AllowWebhookSubscribers:  s.AllowWebhookSubscribers,
+		HiddenFromSearch:         s.HiddenFromSearch,
+		ViewersMustBeTeamMembers: s.ViewersMustBeTeamMembers,
+		NotificationsFromEmail:   s.NotificationsFromEmail,
+		NotificationsEmailFooter: s.NotificationsEmailFooter,
+		CSSBodyBackgroundColor:   s.CSSBodyBackgroundColor,
+		CSSFontColor:             s.CSSFontColor,
+		CSSLightFontColor:        s.CSSLightFontColor,
+		CSSGreens:                s.CSSGreens,
+		CSSYellows:               s.CSSYellows,
+		CSSOranges:               s.CSSOranges,
+		CSSBlues:                 s.CSSBlues,
+		CSSReds:                  s.CSSReds,
+		CSSBorderColor:           s.CSSBorderColor,
+		CSSGraphColor:            s.CSSGraphColor,
+		CSSLinkColor:             s.CSSLinkColor,
+		CSSNoData:                s.CSSNoData,
+	}
+}
+
+// pageFields compares the page settings managed by the manifest with the settings of the page.
+// Settings are matched by their API field names, which the update parameters share with the page.
+func pageFields(want *PageSettings, have *client.Page) ([]FieldChange, error) {
+	wanted, err := jsonFields(want.pageParams())
+	if err != nil {
+		return nil, err
+	}
+	current, err := jsonFields(have)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := []string{}
+	for key := range wanted {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var fields []FieldChange
+	for _, key := range keys {
+		old := fmt.Sprint(current[key])
+		new := fmt.Sprint(wanted[key])
+		if old != new {
+			fields = append(fields, FieldChange{key, old, new})
+		}
+	}
+	return fields, nil
+}
+
+// jsonFields returns the fields of v as encoded in API requests and responses.
+func jsonFields(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	fields := map[string]interface{}{}
+	return fields, json.Unmarshal(data, &fields)
+}
+
+// templateFields returns the fields of an incident template of the manifest created on the page.
+func templateFields(want IncidentTemplate) []FieldChange {
+	var fields []FieldChange
+	add := func(field, new string) {
+		if new != "" {
+			fields = append(fields, FieldChange{field, "", new})
+		}
+	}
+
+	add("title", want.Title)
+	add("body", want.Body)
+	add("update_status", want.UpdateStatus)
+	add("components", sortedList(want.Components))
+	add("should_tweet", strconv.FormatBool(want.ShouldTweet))
+	add("should_send_notifications", strconv.FormatBool(want.ShouldSendNotifications))
+	return fields
+}
+
+// componentFields compares the settings of a component managed by the manifest with the component
+// on the page, which is nil when the component is created.
+func componentFields(want Component, have *client.Component) []FieldChange {
+	if have == nil {
+		have = &client.Component{}
+	}
+
+	// Settings left unset in the manifest are only compared when the component is deleted, so
+	// every setting of a deleted component is listed.
+	var fields []FieldChange
+	add := func(field string, managed bool, old, new string) {
+		if (managed || want.Name == "") && old != new {
+			fields = append(fields, FieldChange{field, old, new})
+		}
+	}
+
+	add("description", want.Description != nil, have.Description, stringValue(want.Description))
+	add("showcase", want.Showcase != nil, boolField(have, have.Showcase), boolValue(want.Showcase))
+	add("only_show_if_degraded", want.OnlyShowIfDegraded != nil, boolField(have, have.OnlyShowIfDegraded), boolValue(want.OnlyShowIfDegraded))
+	add("start_date", want.StartDate != nil, have.StartDate, stringValue(want.StartDate))
+	return fields
+}
+
+// groupFields compares a group of the manifest and its members with the group on the page, which
+// is nil when the group is created.
+func groupFields(want Group, members []string, have *client.ComponentGroup, haveMembers []string) []FieldChange {
+	var fields []FieldChange
+
+	// As for components, an unset description is only compared when the group is deleted.
+	haveDescription := ""
+	if have != nil {
+		haveDescription = have.Description
+	}
+	if (want.Description != nil || want.Name == "") && haveDescription != stringValue(want.Description) {
+		fields = append(fields, FieldChange{"description", haveDescription, stringValue(want.Description)})
+	}
+
+	old := sortedList(haveMembers)
+	new := sortedList(members)
+	if old != new {
+		fields = append(fields, FieldChange{"components", old, new})
+	}
+	return fields
+}
+
+// deletedFields lists every field of a deleted resource as removed.
+func deletedFields(fields []FieldChange) []FieldChange {
+	for i := range fields {
+		fields[i].New = ""
+	}
+	return fields
+}
+
+func liveComponents(page *Page) (map[string]*client.Component, error) {
+	components := map[string]*client.Component{}
+	for i, c := range page.Components {
+		if c.Group {
+			continue
+		}
+		if _, ok := components[c.Name]; ok {
+			return nil, fmt.Errorf("the page has more than one component named %q, rename one of them before using a manifest", c.Name)
+		}
+		components[c.Name] = &page.Components[i]
+	}
+	return components, nil
+}
+
+func liveGroups(page *Page) (map[string]*client.ComponentGroup, error) {
+	groups := map[string]*client.ComponentGroup{}
+	for i, g := range page.Groups {
+		if _, ok := groups[g.Name]; ok {
+			return nil, fmt.Errorf("the page has more than one component group named %q, rename one of them before using a manifest", g.Name)
+		}
+		groups[g.Name] = &page.Groups[i]
+	}
+	return groups, nil
+}
+
+func liveMetrics(page *Page) (map[string]*client.Metric, error) {
+	metrics := map[string]*client.Metric{}
+	for i, metric := range page.Metrics {
+		if _, ok := metrics[metric.Name]; ok {
+			return nil, fmt.Errorf("the page has more than one metric named %q, rename one of them before using a manifest", metric.Name)
+		}
+		metrics[metric.Name] = &page.Metrics[i]
+	}
+	return metrics, nil
+}
+
+func liveTemplates(page *Page) (map[string]*client.IncidentTemplate, error) {
+	templates := map[string]*client.IncidentTemplate{}
+	for i, t := range page.IncidentTemplates {
+		if _, ok := templates[t.Name]; ok {
+			return nil, fmt.Errorf("the page has more than one incident template named %q, rename one of them before using a manifest", t.Name)
+		}
+		templates[t.Name] = &page.IncidentTemplates[i]
+	}
+	return templates, nil
+}
+
+func sortedComponents(components map[string]*client.Component) []*client.Component {
+	list := []*client.Component{}
+	for _, c := range components {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Position < list[j].Position })
+	return list
+}
+
+func sortedGroups(groups map[string]*client.ComponentGroup) []*client.ComponentGroup {
+	list := []*client.ComponentGroup{}
+	for _, g := range groups {
+		list = append(list, g)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Position < list[j].Position })
+	return list
+}
+
+// boolField formats a boolean of a live component, empty for a component that does not exist yet.
+func boolField(have *client.Component, value bool) string {
+	if have.ID == "" {
+		return ""
+	}
+	return strconv.FormatBool(value)
+}
+
+func boolValue(b *bool) string {
+	if b == nil {
+		return ""
+	}
+	return strconv.FormatBool(*b)
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func intValue(i *int) string {
+	if i == nil {
+		return ""
+	}
+	return strconv.Itoa(*i)
+}
+
+func floatValue(f *float64) string {
+	if f == nil {
+		return ""
+	}
+	return formatFloat(*f)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+func sortedList(names []string) string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ", ")
+}
@@ -0,0 +1,206 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package manifest
+
+import (
+	"../client"
+	"reflect"
+	"testing"
+)
+
+func stringPtr(s string) *string { return &s }
+
+// livePage has a Core group of API and Web, a Legacy component outside any group, Latency and
+// Errors metrics and an Outage incident template.
+func livePage() *Page {
+	return &Page{
+		Settings: &client.Page{ID: "pppppppppp01", Name: "Acme", TimeZone: "UTC", AllowEmailSubscribers: true},
+		Components: []client.Component{
+			{ID: "c1", Name: "API", GroupID: "g1", Position: 1},
+			{ID: "c2", Name: "Web", GroupID: "g1", Position: 2},
+			{ID: "c3", Name: "Legacy", Position: 3, Showcase: true},
+			{ID: "g1", Name: "Core", Group: true, Position: 4},
+		},
+		Groups: []client.ComponentGroup{
+			{ID: "g1", Name: "Core", Description: "Core services", Components: []string{"c1", "c2"}},
+		},
+		Metrics: []client.Metric{
+			{ID: "m1", Name: "Latency", Suffix: "ms", Display: true},
+			{ID: "m2", Name: "Errors", Display: true},
+		},
+		IncidentTemplates: []client.IncidentTemplate{
+			{ID: "t1", Name: "Outage", Title: "API down", Body: "Investigating", Components: []client.Component{{ID: "c1", Name: "API"}}},
+		},
+	}
+}
+
+// current declares the live page as it is.
+func current() *Manifest {
+	return &Manifest{
+		Groups: []Group{{Name: "Core", Description: stringPtr("Core services")}},
+		Components: []Component{
+			{Name: "API", Group: "Core"},
+			{Name: "Web", Group: "Core"},
+			{Name: "Legacy"},
+		},
+	}
+}
+
+func TestPlan(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest func(m *Manifest)
+		prune    bool
+		want     []Change
+	}{
+		{"no changes", func(m *Manifest) {}, false, nil},
+		{"no changes with prune", func(m *Manifest) {}, true, nil},
+		{"unset settings are not managed", func(m *Manifest) {
+			m.Components[2].Showcase = nil
+		}, false, nil},
+		{"create a component in a group", func(m *Manifest) {
+			m.Components = append(m.Components, Component{Name: "Database", Group: "Core", Description: stringPtr("Primary database")})
+		}, false, []Change{
+			{Action: Create, Kind: KindComponent, Name: "Database", Fields: []FieldChange{{"description", "", "Primary database"}}},
+			{Action: Update, Kind: KindGroup, Name: "Core", ID: "g1", Fields: []FieldChange{{"components", "API, Web", "API, Database, Web"}}},
+		}},
+		{"update a component", func(m *Manifest) {
+			m.Components[0].Description = stringPtr("Public API")
+			m.Components[2].Showcase = func(b bool) *bool { return &b }(false)
+		}, false, []Change{
+			{Action: Update, Kind: KindComponent, Name: "API", ID: "c1", Fields: []FieldChange{{"description", "", "Public API"}}},
+			{Action: Update, Kind: KindComponent, Name: "Legacy", ID: "c3", Fields: []FieldChange{{"showcase", "true", "false"}}},
+		}},
+		{"create a group", func(m *Manifest) {
+			m.Components[2].Group = "Old"
+		}, false, []Change{
+			{Action: Create, Kind: KindGroup, Name: "Old", Fields: []FieldChange{{"components", "", "Legacy"}}},
+		}},
+		{"update a group description", func(m *Manifest) {
+			m.Groups[0].Description = stringPtr("Everything")
+		}, false, []Change{
+			{Action: Update, Kind: KindGroup, Name: "Core", ID: "g1", Fields: []FieldChange{{"description", "Core services", "Everything"}}},
+		}},
+		{"missing component kept without prune", func(m *Manifest) {
+			m.Components = m.Components[:2]
+		}, false, nil},
+		{"missing component deleted with prune", func(m *Manifest) {
+			m.Components = m.Components[:2]
+		}, true, []Change{
+			{Action: Delete, Kind: KindComponent, Name: "Legacy", ID: "c3", Fields: []FieldChange{{"showcase", "true", ""}, {"only_show_if_degraded", "false", ""}}},
+		}},
+		{"missing group deleted with prune", func(m *Manifest) {
+			m.Groups = nil
+			m.Components[0].Group = ""
+			m.Components[1].Group = ""
+		}, true, []Change{
+			{Action: Delete, Kind: KindGroup, Name: "Core", ID: "g1", Fields: []FieldChange{{"description", "Core services", ""}, {"components", "API, Web", ""}}},
+		}},
+		{"update page settings", func(m *Manifest) {
+			m.Page = &PageSettings{Name: stringPtr("Acme"), TimeZone: stringPtr("Europe/London"), AllowEmailSubscribers: func(b bool) *bool { return &b }(false)}
+		}, false, []Change{
+			{Action: Update, Kind: KindPage, Name: "Acme", ID: "pppppppppp01", Fields: []FieldChange{
+				{"allow_email_subscribers", "true", "false"}, {"time_zone", "UTC", "Europe/London"},
+			}},
+		}},
+		{"page settings unchanged", func(m *Manifest) {
+			m.Page = &PageSettings{Name: stringPtr("Acme")}
+		}, false, nil},
+		{"create an incident template", func(m *Manifest) {
+			m.IncidentTemplates = []IncidentTemplate{{Name: "Maintenance", Title: "Upgrade", Components: []string{"Web", "API"}, ShouldSendNotifications: true}}
+		}, false, []Change{
+			{Action: Create, Kind: KindTemplate, Name: "Maintenance", Fields: []FieldChange{
+				{"title", "", "Upgrade"}, {"components", "", "API, Web"}, {"should_tweet", "", "false"}, {"should_send_notifications", "", "true"},
+			}},
+		}},
+		{"incident templates on the page are not compared", func(m *Manifest) {
+			m.IncidentTemplates = []IncidentTemplate{{Name: "Outage", Title: "API down", Body: "Investigating", Components: []string{"API", "Web"}}}
+		}, false, nil},
+		{"incident templates are not pruned", func(m *Manifest) {
+			m.IncidentTemplates = []IncidentTemplate{{Name: "Outage", Title: "API down", Body: "Investigating", Components: []string{"API"}}}
+		}, true, nil},
+		{"update a metric", func(m *Manifest) {
+			m.Metrics = []Metric{{Name: "Latency", Suffix: stringPtr("s")}, {Name: "Errors"}}
+		}, false, []Change{
+			{Action: Update, Kind: KindMetric, Name: "Latency", ID: "m1", Fields: []FieldChange{{"suffix", "ms", "s"}}},
+		}},
+		{"metrics not pruned unless declared", func(m *Manifest) {}, true, nil},
+		{"missing metric deleted with prune", func(m *Manifest) {
+			m.Metrics = []Metric{{Name: "Latency"}, {Name: "Uptime", Suffix: stringPtr("%")}}
+		}, true, []Change{
+			{Action: Create, Kind: KindMetric, Name: "Uptime", Fields: []FieldChange{{"provider", "", "Self"}, {"suffix", "", "%"}}},
+			{Action: Delete, Kind: KindMetric, Name: "Errors", ID: "m2", Fields: []FieldChange{
+				{"display", "true", ""}, {"decimal_places", "0", ""}, {"y_axis_min", "0", ""}, {"y_axis_max", "0", ""}, {"y_axis_hidden", "false", ""},
+			}},
+		}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := current()
+			test.manifest(m)
+			got, err := Plan(m, livePage(), test.prune)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) == 0 && len(test.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %+v\nwant %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestPlanDuplicateNames(t *testing.T) {
+	tests := []struct {
+		name string
+		page func(p *Page)
+	}{
+		{"components", func(p *Page) { p.Components = append(p.Components, client.Component{ID: "c4", Name: "API"}) }},
+		{"groups", func(p *Page) { p.Groups = append(p.Groups, client.ComponentGroup{ID: "g2", Name: "Core"}) }},
+		{"metrics", func(p *Page) { p.Metrics = append(p.Metrics, client.Metric{ID: "m3", Name: "Latency"}) }},
+		{"incident templates", func(p *Page) {
+			p.IncidentTemplates = append(p.IncidentTemplates, client.IncidentTemplate{ID: "t2", Name: "Outage"})
+		}},
+	}
+
+	for _, test := range tests {
+		page := livePage()
+		test.page(page)
+		if _, err := Plan(current(), page, false); err == nil {
+			t.Errorf("%s: got no error for duplicate names", test.name)
+		}
+	}
+}
+
+func TestPageParams(t *testing.T) {
+	settings := &PageSettings{Name: stringPtr("Acme"), TimeZone: stringPtr("Europe/London"), HiddenFromSearch: func(b bool) *bool { return &b }(false)}
+	fields, err := pageFields(settings, livePage().Settings)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	params, err := PageParams(settings, fields)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := client.PageParams{TimeZone: stringPtr("Europe/London")}
+	if !reflect.DeepEqual(params, want) {
+		t.Errorf("got %+v, want only the time zone", params)
+	}
+}
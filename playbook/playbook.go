@@ -0,0 +1,166 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Package playbook reads local incident playbooks, which describe the lifecycle of an incident from
+// its first message to its resolution, and keeps track of the incidents created from them.
+package playbook
+
+import (
+	"../client"
+	"../utils"
+	"errors"
+	"fmt"
+	"gopkg.in/yaml.v2"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Stage is a message posted to an incident. Components maps the identifiers or names of the
+// affected components to the status they are set to.
+type Stage struct {
+	Status     string            `yaml:"status"`
+	Body       string            `yaml:"body"`
+	Components map[string]string `yaml:"components,omitempty"`
+}
+
+// Playbook describes an incident: the stage it is created with, and the updates posted one after
+// the other as it is advanced. The name, bodies and component names can hold Go template variables.
+type Playbook struct {
+	Name                 string `yaml:"name"`
+	Stage                `yaml:",inline"`
+	DeliverNotifications *bool   `yaml:"deliver_notifications,omitempty"`
+	Updates              []Stage `yaml:"updates,omitempty"`
+}
+
+// Load reads the playbook called name from dir, stored as name.yaml or name.yml.
+func Load(dir, name string) (*Playbook, error) {
+	var data []byte
+	var err error
+	for _, ext := range []string{".yaml", ".yml"} {
+		data, err = ioutil.ReadFile(filepath.Join(dir, name+ext))
+		if !os.IsNotExist(err) {
+			break
+		}
+	}
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("no playbook %q in %s", name, dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := &Playbook{}
+	if err := yaml.UnmarshalStrict(data, p); err != nil {
+		return nil, fmt.Errorf("error reading playbook %s: %v", name, err)
+	}
+	if err := p.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid playbook %s: %v", name, err)
+	}
+	return p, nil
+}
+
+// Validate checks that the playbook names the incident and only uses valid statuses.
+func (p *Playbook) Validate() error {
+	if p.Name == "" {
+		return errors.New("the incident has no name")
+	}
+	if err := p.Stage.validate("the incident"); err != nil {
+		return err
+	}
+	for i, s := range p.Updates {
+		if err := s.validate(fmt.Sprintf("update %d", i+1)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s Stage) validate(what string) error {
+	if !utils.Contains(client.IncidentStatuses, s.Status) {
+		return fmt.Errorf("%s has the status %q, valid choices are: %s", what, s.Status, strings.Join(client.IncidentStatuses, ", "))
+	}
+	for component, status := range s.Components {
+		if !utils.Contains(client.ComponentStatuses, status) {
+			return fmt.Errorf("%s sets component %q to %q, valid choices are: %s", what, component, status, strings.Join(client.ComponentStatuses, ", "))
+		}
+	}
+	return nil
+}
+
+// Render returns the stage with its Go template variables filled in with vars.
+func (s Stage) Render(vars map[string]string) (Stage, error) {
+	body, err := utils.RenderTemplate("body", s.Body, vars)
+	if err != nil {
+		return s, err
+	}
+
+	components := map[string]string{}
+	for component, status := range s.Components {
+		name, err := utils.RenderTemplate("component "+component, component, vars)
+		if err != nil {
+			return s, err
+		}
+		components[name] = status
+	}
+	return Stage{Status: s.Status, Body: body, Components: components}, nil
+}
+
+// Run is an incident created from a playbook.
+type Run struct {
+	PageID   string            `yaml:"page_id"`
+	Playbook string            `yaml:"playbook"`
+	Vars     map[string]string `yaml:"vars,omitempty"`
+	// Posted is the number of updates of the playbook posted so far.
+	Posted int `yaml:"posted"`
+}
+
+// Runs are the incidents created from playbooks that still have updates to post, keyed by
+// incident identifier.
+type Runs map[string]*Run
+
+// LoadRuns reads the runs recorded in path. A missing file holds no runs.
+func LoadRuns(path string) (Runs, error) {
+	runs := Runs{}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return runs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(data, &runs); err != nil {
+		return nil, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if runs == nil {
+		runs = Runs{}
+	}
+	return runs, nil
+}
+
+// Save writes the runs to path, creating its directory when needed.
+func (r Runs) Save(path string) error {
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, data, 0600)
+}
@@ -0,0 +1,76 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// scissors marks the end of the text edited with EditText. Anything below it is left out.
+const scissors = "# ------------------------ >8 ------------------------"
+
+// EditText opens text in the editor set with the VISUAL or EDITOR environment variables, or vi,
+// and returns the edited text without surrounding blank space. When comment is not empty it is
+// shown below a scissors line, and everything from that line on is removed from the result.
+func EditText(text, comment string) (string, error) {
+	file, err := ioutil.TempFile("", "statuspage-*.md")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(file.Name())
+
+	content := text + "\n"
+	if comment != "" {
+		content += "\n" + scissors + "\n# Do not modify or remove the line above.\n# Everything below it will be ignored.\n\n" + comment + "\n"
+	}
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return "", err
+	}
+	if err := file.Close(); err != nil {
+		return "", err
+	}
+
+	editor := GetEnv("VISUAL")
+	if editor == "" {
+		editor = GetEnv("EDITOR")
+	}
+	if editor == "" {
+		editor = "vi"
+	}
+
+	// The editor may be set with arguments, such as "code --wait", so it is run by the shell.
+	cmd := exec.Command("sh", "-c", editor+` "$@"`, editor, file.Name())
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stderr
+	cmd.Stderr = os.Stderr
+	if err := cmd.Run(); err != nil {
+		return "", err
+	}
+
+	edited, err := ioutil.ReadFile(file.Name())
+	if err != nil {
+		return "", err
+	}
+	text = string(edited)
+	if i := strings.Index(text, scissors); i >= 0 {
+		text = text[:i]
+	}
+	return strings.TrimSpace(text), nil
+}
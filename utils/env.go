@@ -16,10 +16,7 @@ Copyright © 2020 Appvia Ltd <info@appvia.io>
 
 package utils
 
-import (
-	"fmt"
-	"os"
-)
+import "os"
 
 func GetEnv(str string) string {
 	envVar := os.Getenv(str)
@@ -27,10 +24,7 @@ func GetEnv(str string) string {
 }
 
 func DefaultToEnv(keyFromEnv string, keyFromFlag string) string {
-	if keyFromFlag == "" && keyFromEnv == "" {
-		fmt.Println("Set API_KEY as environment variable or specify --api-key flag or -k flag.")
-		os.Exit(1)
-	} else if keyFromFlag == "" && keyFromEnv != "" {
+	if keyFromFlag == "" {
 		return keyFromEnv
 	}
 	return keyFromFlag
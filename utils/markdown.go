@@ -0,0 +1,84 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"regexp"
+	"strings"
+)
+
+var htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+var heading = regexp.MustCompile(`^#{1,6}\s+(.*?)(\s+#+)?$`)
+var bullet = regexp.MustCompile(`^(\s*)[*+]\s+`)
+var link = regexp.MustCompile(`!?\[([^\]]*)\]\(([^)\s]+)\)`)
+var blankLines = regexp.MustCompile(`\n{3,}`)
+
+// NormalizeMarkdown rewrites Markdown as the plain text shown in incident updates and sent in
+// notifications. Line endings become \n, HTML comments and code fences are removed, headings
+// become plain lines, * and + bullets become - bullets, links and images become "text (url)",
+// trailing spaces are trimmed and runs of blank lines are collapsed into one. Lines within code
+// fences, comments included, are kept as they are.
+func NormalizeMarkdown(text string) string {
+	text = strings.Replace(text, "\r\n", "\n", -1)
+	text = strings.Replace(text, "\r", "\n", -1)
+
+	lines := []string{}
+	// prose holds the lines read since the last code fence. Comments may span several lines, so
+	// they are removed from the whole run of lines before it is rewritten line by line.
+	prose := []string{}
+	flush := func() {
+		if len(prose) == 0 {
+			return
+		}
+		for _, line := range strings.Split(htmlComment.ReplaceAllString(strings.Join(prose, "\n"), ""), "\n") {
+			lines = append(lines, normalizeLine(line))
+		}
+		prose = prose[:0]
+	}
+
+	code := false
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			flush()
+			code = !code
+			continue
+		}
+		if code {
+			lines = append(lines, line)
+		} else {
+			prose = append(prose, line)
+		}
+	}
+	flush()
+
+	text = blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text)
+}
+
+// normalizeLine rewrites the headings, bullets, links and images of a line outside code fences.
+func normalizeLine(line string) string {
+	line = strings.TrimRight(line, " \t")
+	line = heading.ReplaceAllString(line, "$1")
+	line = bullet.ReplaceAllString(line, "$1- ")
+	return link.ReplaceAllStringFunc(line, func(l string) string {
+		m := link.FindStringSubmatch(l)
+		if m[1] == "" || m[1] == m[2] {
+			return m[2]
+		}
+		return m[1] + " (" + m[2] + ")"
+	})
+}
@@ -0,0 +1,42 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import "testing"
+
+func TestNormalizeMarkdown(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want string
+	}{
+		{"line endings", "one\r\ntwo\rthree", "one\ntwo\nthree"},
+		{"trailing spaces and blank lines", "one  \n\n\n\ntwo\t\n\n", "one\n\ntwo"},
+		{"headings", "# Outage\n## Update ##\n#hashtag", "Outage\nUpdate\n#hashtag"},
+		{"bullets", "* one\n+ two\n  * nested\n- three", "- one\n- two\n  - nested\n- three"},
+		{"links", "See [the status](https://example.com) and [https://x.io](https://x.io)", "See the status (https://example.com) and https://x.io"},
+		{"images", "![Latency graph](https://example.com/g.png) and ![](https://example.com/h.png)", "Latency graph (https://example.com/g.png) and https://example.com/h.png"},
+		{"comments", "before<!-- note -->\n<!--\nmulti\nline\n-->\nafter", "before\n\nafter"},
+		{"code fences", "run:\n```\n# not a heading\n* not a bullet  \n```\ndone", "run:\n# not a heading\n* not a bullet\ndone"},
+		{"comments in code fences", "```html\n<!-- kept -->\n```\n<!-- removed -->text", "<!-- kept -->\ntext"},
+	}
+
+	for _, test := range tests {
+		if got := NormalizeMarkdown(test.text); got != test.want {
+			t.Errorf("%s: NormalizeMarkdown(%q) = %q, want %q", test.name, test.text, got, test.want)
+		}
+	}
+}
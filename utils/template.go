@@ -0,0 +1,37 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"text/template"
+)
+
+// RenderTemplate fills in the Go template variables of text, such as {{.region}}, with vars.
+// Variables missing from vars are reported rather than left empty.
+func RenderTemplate(name, text string, vars map[string]string) (string, error) {
+	t, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("error parsing %s: %v", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := t.Execute(&out, vars); err != nil {
+		return "", fmt.Errorf("error filling in %s: %v", name, err)
+	}
+	return out.String(), nil
+}
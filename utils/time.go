@@ -0,0 +1,97 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+var dateTimeLayouts = []string{"2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02"}
+var clockLayouts = []string{"15:04:05", "15:04", "3pm", "3:04pm"}
+
+// ParseTime parses a time given on the command line, relative to now. It accepts RFC 3339 times,
+// "now", durations from now such as "+2h" or "in 90m", and dates or days with an optional time of
+// day and time zone, such as "2020-06-01 02:00", "tomorrow 02:00 Europe/London" or "today 18:30 UTC".
+// Times without a time zone are in the local time zone.
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	if strings.EqualFold(value, "now") {
+		return now, nil
+	}
+	// Duration units are lower case, so the whole value is lowered to accept "In 2h" and "+2H".
+	if lower := strings.ToLower(value); strings.HasPrefix(lower, "+") || strings.HasPrefix(lower, "in ") {
+		d, err := time.ParseDuration(strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(lower, "+"), "in ")))
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid time %q: %v", value, err)
+		}
+		return now.Add(d), nil
+	}
+
+	fields := strings.Fields(value)
+	location := now.Location()
+	if len(fields) > 1 {
+		if loc, err := time.LoadLocation(fields[len(fields)-1]); err == nil {
+			location = loc
+			fields = fields[:len(fields)-1]
+		}
+	}
+	local := now.In(location)
+
+	if len(fields) > 0 {
+		var day time.Time
+		switch strings.ToLower(fields[0]) {
+		case "today":
+			day = local
+		case "tomorrow":
+			day = local.AddDate(0, 0, 1)
+		}
+		if !day.IsZero() {
+			clock := time.Time{}
+			if len(fields) == 2 {
+				var err error
+				if clock, err = parseClock(fields[1]); err != nil {
+					return time.Time{}, fmt.Errorf("invalid time %q: %v", value, err)
+				}
+			} else if len(fields) > 2 {
+				return time.Time{}, fmt.Errorf("invalid time %q", value)
+			}
+			return time.Date(day.Year(), day.Month(), day.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, location), nil
+		}
+	}
+
+	joined := strings.Join(fields, " ")
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, joined, location); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q, use a time such as \"2020-06-01 02:00\", \"tomorrow 02:00 Europe/London\", \"+2h\" or RFC 3339", value)
+}
+
+func parseClock(value string) (time.Time, error) {
+	for _, layout := range clockLayouts {
+		if t, err := time.Parse(layout, strings.ToLower(value)); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time of day %q", value)
+}
@@ -0,0 +1,79 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	london, err := time.LoadLocation("Europe/London")
+	if err != nil {
+		t.Skip("time zone database not available:", err)
+	}
+	utc := time.UTC
+	now := time.Date(2020, 6, 1, 10, 30, 0, 0, utc)
+
+	tests := []struct {
+		value string
+		want  time.Time
+	}{
+		{"2020-06-02T02:00:00Z", time.Date(2020, 6, 2, 2, 0, 0, 0, utc)},
+		{"2020-06-02T02:00:00+02:00", time.Date(2020, 6, 2, 0, 0, 0, 0, utc)},
+		{"now", now},
+		{" Now ", now},
+		{"+2h", now.Add(2 * time.Hour)},
+		{"+2H", now.Add(2 * time.Hour)},
+		{"+1h30m", now.Add(90 * time.Minute)},
+		{"in 90m", now.Add(90 * time.Minute)},
+		{"In 2h", now.Add(2 * time.Hour)},
+		{"IN  45s", now.Add(45 * time.Second)},
+		{"today", time.Date(2020, 6, 1, 0, 0, 0, 0, utc)},
+		{"today 18:30", time.Date(2020, 6, 1, 18, 30, 0, 0, utc)},
+		{"Tomorrow 02:00", time.Date(2020, 6, 2, 2, 0, 0, 0, utc)},
+		{"tomorrow 3pm", time.Date(2020, 6, 2, 15, 0, 0, 0, utc)},
+		{"tomorrow 3:15PM", time.Date(2020, 6, 2, 15, 15, 0, 0, utc)},
+		{"tomorrow 02:00 Europe/London", time.Date(2020, 6, 2, 2, 0, 0, 0, london)},
+		{"today 18:30 UTC", time.Date(2020, 6, 1, 18, 30, 0, 0, utc)},
+		{"2020-06-01 02:00", time.Date(2020, 6, 1, 2, 0, 0, 0, utc)},
+		{"2020-06-01 02:00:30", time.Date(2020, 6, 1, 2, 0, 30, 0, utc)},
+		{"2020-06-01T02:00", time.Date(2020, 6, 1, 2, 0, 0, 0, utc)},
+		{"2020-06-01", time.Date(2020, 6, 1, 0, 0, 0, 0, utc)},
+		{"2020-06-01 02:00 Europe/London", time.Date(2020, 6, 1, 2, 0, 0, 0, london)},
+	}
+
+	for _, test := range tests {
+		got, err := ParseTime(test.value, now)
+		if err != nil {
+			t.Errorf("ParseTime(%q) returned error %v", test.value, err)
+			continue
+		}
+		if !got.Equal(test.want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	now := time.Date(2020, 6, 1, 10, 30, 0, 0, time.UTC)
+
+	for _, value := range []string{"", "soon", "+2", "in", "in two hours", "tomorrow 25:00", "tomorrow 02:00 extra words", "2020-13-01", "yesterday"} {
+		if got, err := ParseTime(value, now); err == nil {
+			t.Errorf("ParseTime(%q) = %v, want an error", value, got)
+		}
+	}
+}
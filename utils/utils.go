@@ -23,3 +23,25 @@ func Contains(arr []string, str string) bool {
 	}
 	return false
 }
+
+// AppendMissing returns arr followed by the strings of add it does not contain yet.
+func AppendMissing(arr []string, add ...string) []string {
+	out := append([]string{}, arr...)
+	for _, a := range add {
+		if !Contains(out, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
+
+// Remove returns the strings of arr that are not in remove.
+func Remove(arr []string, remove ...string) []string {
+	out := []string{}
+	for _, a := range arr {
+		if !Contains(remove, a) {
+			out = append(out, a)
+		}
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+/*
+Copyright © 2020 Appvia Ltd <info@appvia.io>
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package utils
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAppendMissing(t *testing.T) {
+	arr := []string{"a", "b"}
+	if got, want := AppendMissing(arr, "b", "c", "c"), []string{"a", "b", "c"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("AppendMissing = %v, want %v", got, want)
+	}
+	if want := []string{"a", "b"}; !reflect.DeepEqual(arr, want) {
+		t.Errorf("AppendMissing changed its argument to %v", arr)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	for _, tc := range []struct {
+		arr, remove, want []string
+	}{
+		{[]string{"a", "b", "c"}, []string{"b"}, []string{"a", "c"}},
+		{[]string{"a"}, []string{"a"}, []string{}},
+		{[]string{"a"}, []string{"z"}, []string{"a"}},
+		{nil, []string{"a"}, []string{}},
+	} {
+		if got := Remove(tc.arr, tc.remove...); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Remove(%v, %v) = %v, want %v", tc.arr, tc.remove, got, tc.want)
+		}
+	}
+}